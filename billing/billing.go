@@ -0,0 +1,67 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package billing
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Rates contains the hourly rates used to bill time spent, the most specific rate is used in
+// the order project, tag, author email, author name and then the default rate
+type Rates struct {
+	Currency string `json:"currency"`
+	// Increment is the minimum billable increment in minutes
+	Increment int                `json:"increment"`
+	Default   float64            `json:"default"`
+	Projects  map[string]float64 `json:"projects"`
+	Tags      map[string]float64 `json:"tags"`
+	Authors   map[string]float64 `json:"authors"`
+}
+
+// Rate returns the hourly rate for a project, its tags and an author
+func (r Rates) Rate(project string, tags []string, name, email string) float64 {
+	if rate, ok := r.Projects[project]; ok {
+		return rate
+	}
+
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	for _, t := range sorted {
+		if rate, ok := r.Tags[t]; ok {
+			return rate
+		}
+	}
+
+	// an email rate takes precedence over a name rate, keys differing only by case are used in sorted order
+	authors := make([]string, 0, len(r.Authors))
+	for author := range r.Authors {
+		authors = append(authors, author)
+	}
+	sort.Strings(authors)
+	for _, match := range []string{email, name} {
+		for _, author := range authors {
+			if strings.EqualFold(author, match) {
+				return r.Authors[author]
+			}
+		}
+	}
+	return r.Default
+}
+
+// Billable returns seconds rounded up to the minimum billable increment
+func (r Rates) Billable(secs int) int {
+	inc := r.Increment * 60
+	if inc <= 0 || secs <= 0 {
+		return secs
+	}
+	return (secs + inc - 1) / inc * inc
+}
+
+// Amount returns the amount billed for seconds at an hourly rate rounded to cents
+func Amount(secs int, rate float64) float64 {
+	return math.Round(float64(secs)/3600*rate*100) / 100
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package billing
+
+import "testing"
+
+func TestRate(t *testing.T) {
+	r := Rates{
+		Default:  50,
+		Projects: map[string]float64{"gtm": 120},
+		Tags:     map[string]float64{"acme": 100, "work": 90},
+		Authors:  map[string]float64{"jane@example.com": 150, "Jane": 140, "JANE@example.com": 160, "Ann": 70},
+	}
+
+	tests := []struct {
+		project, name, email string
+		tags                 []string
+		want                 float64
+	}{
+		{"gtm", "Jane", "jane@example.com", []string{"acme"}, 120},
+		{"other", "Jane", "jane@example.com", []string{"work", "acme"}, 100},
+		{"other", "Jane", "JANE@example.com", []string{}, 160},
+		{"other", "Jane", "jane@other.com", []string{}, 140},
+		{"other", "Ann", "jane@example.com", []string{}, 160},
+		{"other", "John", "john@example.com", []string{}, 50},
+	}
+
+	for _, tc := range tests {
+		got := r.Rate(tc.project, tc.tags, tc.name, tc.email)
+		if got != tc.want {
+			t.Errorf("Rate(%s, %v, %s, %s) want %.2f got %.2f", tc.project, tc.tags, tc.name, tc.email, tc.want, got)
+		}
+	}
+}
+
+func TestBillable(t *testing.T) {
+	r := Rates{Increment: 15}
+
+	tests := map[int]int{0: 0, 1: 900, 900: 900, 901: 1800}
+	for secs, want := range tests {
+		if got := r.Billable(secs); got != want {
+			t.Errorf("Billable(%d) want %d got %d", secs, want, got)
+		}
+	}
+
+	if got := (Rates{}).Billable(61); got != 61 {
+		t.Errorf("Billable(61) without increment want 61 got %d", got)
+	}
+}
+
+func TestAmount(t *testing.T) {
+	if got := Amount(900, 120); got != 30 {
+		t.Errorf("Amount(900, 120) want 30.00 got %.2f", got)
+	}
+	if got := Amount(100, 100); got != 2.78 {
+		t.Errorf("Amount(100, 100) want 2.78 got %.2f", got)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/DEVELOPEST/gtm-core/note"
+	"github.com/DEVELOPEST/gtm-core/scm"
+)
+
+// version is incremented when the cache format changes, caches of other versions are rebuilt
+const version = 3
+
+// Dir returns the cache directory, $XDG_CACHE_HOME/gtm if set, otherwise ~/.cache/gtm
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "gtm"), nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(u.HomeDir, ".cache", "gtm"), nil
+}
+
+// Clear removes the cached notes of all repositories
+func Clear() error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("Unable to clear cache %s, %s", dir, err)
+	}
+	return nil
+}
+
+// Entry is a commit's metadata and parsed note,
+// the author is kept as recorded in the commit and not mapped with the .mailmap
+type Entry struct {
+	Commit scm.CommitNote
+	Note   note.CommitNote
+	// NoteIDs are the note blob ids the note was read from, used to detect changed notes
+	NoteIDs []string
+	// Stats is the detail of the commit's diff stats
+	Stats scm.StatsDetail
+}
+
+// Cache contains the commits read for a repository, keyed by commit id.
+// Entries are valid for the notes ref commit they were read with.
+type Cache struct {
+	Version  int
+	NotesOID string
+	Entries  map[string]Entry
+
+	path   string
+	reader *scm.NoteReader
+	dirty  bool
+}
+
+// Open loads the cache of the repository at repoPath for the notes read by reader.
+// The cache is updated incrementally when notes are added and rebuilt when the notes ref is rewritten.
+// An empty cache is returned with the error if the cache can not be read.
+func Open(repoPath string, reader *scm.NoteReader) (*Cache, error) {
+	c := &Cache{
+		Version:  version,
+		NotesOID: reader.NotesOID(),
+		Entries:  map[string]Entry{},
+		reader:   reader,
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return c, err
+	}
+	p, err := filepath.Abs(repoPath)
+	if err != nil {
+		return c, err
+	}
+	c.path = filepath.Join(dir, fmt.Sprintf("%x.json", sha1.Sum([]byte(p))))
+
+	raw, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, fmt.Errorf("Unable to read cache %s, %s", c.path, err)
+	}
+
+	saved := Cache{}
+	if err := json.Unmarshal(raw, &saved); err != nil || saved.Version != version {
+		// rebuild a corrupt or outdated cache
+		c.dirty = true
+		return c, nil
+	}
+
+	switch {
+	case saved.NotesOID == c.NotesOID:
+		c.Entries = saved.Entries
+	case reader.NotesDescendantOf(saved.NotesOID):
+		// notes were added, keep the entries whose notes did not change
+		// and drop the commits that no longer have notes
+		for id, e := range saved.Entries {
+			ids := reader.NoteIDs(id, e.Commit.Message)
+			if len(ids) > 0 && equal(e.NoteIDs, ids) {
+				c.Entries[id] = e
+			}
+		}
+		c.dirty = true
+	default:
+		// the notes ref was rewritten
+		c.dirty = true
+	}
+	if c.Entries == nil {
+		c.Entries = map[string]Entry{}
+	}
+	return c, nil
+}
+
+// Get returns the cached entry for a commit id
+func (c *Cache) Get(commitID string) (Entry, bool) {
+	e, ok := c.Entries[commitID]
+	return e, ok
+}
+
+// Put adds or replaces the entry for a commit id
+func (c *Cache) Put(commitID string, e Entry) {
+	// the raw note is cached parsed and the object id is not needed for reports
+	e.Commit.Note = ""
+	e.Commit.OID = nil
+	e.NoteIDs = c.reader.NoteIDs(commitID, e.Commit.Message)
+	c.Entries[commitID] = e
+	c.dirty = true
+}
+
+// Save writes the cache if it was changed,
+// the cache is written to a temporary file and renamed so concurrent reports never read a partial cache
+func (c *Cache) Save() error {
+	if !c.dirty || c.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("Unable to create cache directory, %s", err)
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("Unable to write cache %s, %s", c.path, err)
+	}
+	_, err = f.Write(raw)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("Unable to write cache %s, %s", c.path, err)
+	}
+	c.dirty = false
+	return nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
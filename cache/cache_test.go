@@ -0,0 +1,109 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/note"
+	"github.com/DEVELOPEST/gtm-core/scm"
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+func TestCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "gtm-cache")
+	util.CheckFatal(t, err)
+	defer os.RemoveAll(cacheDir)
+	defer os.Setenv("XDG_CACHE_HOME", os.Getenv("XDG_CACHE_HOME"))
+	os.Setenv("XDG_CACHE_HOME", cacheDir)
+
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	workdir := repo.Workdir()
+
+	repo.SaveFile("README", "", "first")
+	first := repo.Commit(repo.Stage("README")).String()
+	repo.SaveFile("README", "", "second")
+	second := repo.Commit(repo.Stage("README")).String()
+
+	util.CheckFatal(t, scm.CreateNote("[ver:1,total:60,branch:master]\nREADME:60,1458496800:60,m", "gtm-data", first, workdir))
+
+	open := func() (*Cache, *scm.NoteReader) {
+		reader, err := scm.NewNoteReader("gtm-data", scm.StatsOff, workdir)
+		util.CheckFatal(t, err)
+		c, err := Open(workdir, reader)
+		util.CheckFatal(t, err)
+		return c, reader
+	}
+
+	c, reader := open()
+	if _, ok := c.Get(first); ok {
+		t.Errorf("Get(%s) want not cached, got cached", first)
+	}
+	for _, id := range []string{first, second} {
+		n, err := reader.ReadUnmapped(id)
+		util.CheckFatal(t, err)
+		cn, err := note.UnMarshal(n.Note)
+		util.CheckFatal(t, err)
+		c.Put(id, Entry{Commit: n, Note: cn})
+	}
+	util.CheckFatal(t, c.Save())
+	reader.Free()
+
+	files, err := ioutil.ReadDir(filepath.Join(cacheDir, "gtm"))
+	util.CheckFatal(t, err)
+	if len(files) != 1 {
+		t.Errorf("Save() want only the cache file, got %d files", len(files))
+	}
+
+	// authors are mapped with the current .mailmap
+	repo.SaveFile(".mailmap", "", "Hacker <hacker@example.com> Rand Om Hacker <random@hacker.com>\n")
+	c, reader = open()
+	e, ok := c.Get(first)
+	if !ok || e.Note.Total() != 60 {
+		t.Errorf("Get(%s) want cached note with total 60, got %t %+v", first, ok, e.Note)
+	}
+	if n := reader.ResolveAuthor(e.Commit); n.Author != "Hacker" || n.Email != "hacker@example.com" {
+		t.Errorf("ResolveAuthor(%+v) want Hacker <hacker@example.com>, got %s <%s>", e.Commit, n.Author, n.Email)
+	}
+	reader.Free()
+
+	// adding a note keeps the entries whose notes did not change
+	util.CheckFatal(t, scm.CreateNote("[ver:1,total:30,branch:master]\nREADME:30,1458496800:30,m", "gtm-data", second, workdir))
+	c, reader = open()
+	if _, ok := c.Get(first); !ok {
+		t.Errorf("Get(%s) after adding a note want cached, got not cached", first)
+	}
+	if _, ok := c.Get(second); ok {
+		t.Errorf("Get(%s) after adding its note want not cached, got cached", second)
+	}
+	reader.Free()
+
+	// commits without notes are dropped when the cache is rebuilt
+	repo.SaveFile("README", "", "third")
+	third := repo.Commit(repo.Stage("README")).String()
+	repo.SaveFile("README", "", "fourth")
+	fourth := repo.Commit(repo.Stage("README")).String()
+	c, reader = open()
+	c.Put(third, Entry{})
+	util.CheckFatal(t, c.Save())
+	reader.Free()
+	util.CheckFatal(t, scm.CreateNote("[ver:1,total:30,branch:master]\nREADME:30,1458496800:30,m", "gtm-data", fourth, workdir))
+	c, reader = open()
+	if _, ok := c.Get(third); ok {
+		t.Errorf("Get(%s) after rebuilding want commit without notes dropped, got cached", third)
+	}
+	reader.Free()
+
+	util.CheckFatal(t, Clear())
+	c, reader = open()
+	defer reader.Free()
+	if len(c.Entries) != 0 {
+		t.Errorf("Clear() want empty cache, got %d entries", len(c.Entries))
+	}
+}
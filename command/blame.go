@@ -0,0 +1,111 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/report"
+	"github.com/DEVELOPEST/gtm-core/scm"
+	"github.com/mitchellh/cli"
+)
+
+// BlameCmd contains method for blame command
+type BlameCmd struct {
+	UI cli.Ui
+}
+
+// NewBlame returns a new BlameCmd struct
+func NewBlame() (cli.Command, error) {
+	return BlameCmd{}, nil
+}
+
+// Help returns help for blame command
+func (c BlameCmd) Help() string {
+	helpText := `
+Usage: gtm blame [options] <file>
+
+  Show the commit that last changed each line of a file, its author and the time the commit recorded for the file,
+  followed by a summary of the commits by time. Lines are blamed in the head commit.
+
+Options:
+
+  -terminal-off=false        Exclude time spent in terminal (Terminal plug-in is required)
+  -app-off=false             Exclude time spent in apps
+  -force-color=false         Always output color even if no terminal is detected, i.e 'gtm blame -color | less -R'
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Run executes blame command with args
+func (c BlameCmd) Run(args []string) int {
+	var color, terminalOff, appOff bool
+	cmdFlags := flag.NewFlagSet("blame", flag.ContinueOnError)
+	cmdFlags.BoolVar(&color, "force-color", false, "")
+	cmdFlags.BoolVar(&terminalOff, "terminal-off", false, "")
+	cmdFlags.BoolVar(&appOff, "app-off", false, "")
+	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(cmdFlags.Args()) != 1 {
+		c.UI.Error("Unable to blame, one file must be provided")
+		return 1
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	projPath, err := scm.GitRepoPath()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	projPath, err = scm.Workdir(projPath)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	p := cmdFlags.Args()[0]
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(wd, p)
+	}
+	sourcePath, err := filepath.Rel(projPath, p)
+	if err != nil || sourcePath == ".." || strings.HasPrefix(sourcePath, ".."+string(filepath.Separator)) {
+		c.UI.Error(fmt.Sprintf("%s is outside repository %s", p, projPath))
+		return 1
+	}
+
+	lines, err := scm.Blame(filepath.ToSlash(sourcePath), projPath)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	out, err := report.Blame(projPath, lines, report.OutputOptions{
+		TerminalOff: terminalOff,
+		AppOff:      appOff,
+		Color:       color})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Output(out)
+	return 0
+}
+
+// Synopsis return help for blame command
+func (c BlameCmd) Synopsis() string {
+	return "Show the time recorded by the commits that last changed each line of a file"
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+func TestBlame(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "package event\r\n")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go")))
+
+	ui := new(cli.MockUi)
+	args := []string{"-yes"}
+	if rc := (CommitCmd{UI: ui}).Run(args); rc != 0 {
+		t.Fatalf("gtm commit(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	args = []string{filepath.Join("event", "event.go")}
+	rc := (BlameCmd{UI: ui}).Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm blame(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	for _, want := range []string{"package event", "3m  0s", "1 line"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm blame(%+v), want %q got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+	if strings.Contains(ui.OutputWriter.String(), "\r") {
+		t.Errorf("gtm blame(%+v), want lines without carriage returns got %q", args, ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	args = []string{"not-a-file.go"}
+	if rc := (BlameCmd{UI: ui}).Run(args); rc != 1 {
+		t.Errorf("gtm blame(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestBlameInvalidOption(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := BlameCmd{UI: ui}
+
+	args := []string{"-invalid"}
+	rc := c.Run(args)
+
+	if rc != 1 {
+		t.Errorf("gtm blame(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Usage:") {
+		t.Errorf("gtm blame(%+v), want 'Usage:'  got %d, %s", args, rc, ui.OutputWriter.String())
+	}
+}
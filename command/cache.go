@@ -0,0 +1,57 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/cache"
+	"github.com/mitchellh/cli"
+)
+
+// CacheClearCmd contains method for cache clear method
+type CacheClearCmd struct {
+	UI cli.Ui
+}
+
+// NewCacheClear returns a new CacheClearCmd struct
+func NewCacheClear() (cli.Command, error) {
+	return CacheClearCmd{}, nil
+}
+
+// Help returns help for the cache clear command
+func (c CacheClearCmd) Help() string {
+	helpText := `
+Usage: gtm cache clear
+
+  Deletes the report cache of all git repositories.
+
+  Reports cache commit notes in ~/.cache/gtm, or $XDG_CACHE_HOME/gtm if set.
+  The cache is updated when notes are added and rebuilt when notes are rewritten,
+  clearing it is only needed to reclaim disk space.
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Run executes cache clear command with args
+func (c CacheClearCmd) Run(args []string) int {
+	cmdFlags := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := cache.Clear(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// Synopsis return help for cache clear command
+func (c CacheClearCmd) Synopsis() string {
+	return "Delete the report cache"
+}
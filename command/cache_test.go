@@ -0,0 +1,39 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+func TestCacheClear(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "gtm-cache")
+	util.CheckFatal(t, err)
+	defer os.RemoveAll(cacheDir)
+	defer os.Setenv("XDG_CACHE_HOME", os.Getenv("XDG_CACHE_HOME"))
+	os.Setenv("XDG_CACHE_HOME", cacheDir)
+
+	util.CheckFatal(t, os.MkdirAll(filepath.Join(cacheDir, "gtm"), 0700))
+	util.CheckFatal(t, ioutil.WriteFile(filepath.Join(cacheDir, "gtm", "repo.json"), []byte("{}"), 0600))
+
+	ui := new(cli.MockUi)
+	c := CacheClearCmd{UI: ui}
+
+	args := []string{}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm cache clear(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "gtm")); !os.IsNotExist(err) {
+		t.Errorf("gtm cache clear(%+v), want cache directory removed, got %v", args, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/mitchellh/cli"
+)
+
+// CheckIgnoreCmd contains method for check-ignore command
+type CheckIgnoreCmd struct {
+	UI cli.Ui
+}
+
+// NewCheckIgnore returns a new CheckIgnoreCmd struct
+func NewCheckIgnore() (cli.Command, error) {
+	return CheckIgnoreCmd{}, nil
+}
+
+// Help returns help for check-ignore command
+func (c CheckIgnoreCmd) Help() string {
+	helpText := `
+Usage: gtm check-ignore <path>...
+
+  Show if paths are ignored for time tracking and the rule that decides it.
+
+  Rules are read from .gtmignore in the repository root and in .gtm/, using gitignore syntax.
+  Events are not recorded for ignored files and pending events of ignored files are dropped.
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Run executes check-ignore command with args
+func (c CheckIgnoreCmd) Run(args []string) int {
+	cmdFlags := flag.NewFlagSet("check-ignore", flag.ContinueOnError)
+	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(cmdFlags.Args()) == 0 {
+		c.UI.Error("Unable to check, path not provided")
+		return 1
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	rootPath, _, err := project.Paths(wd)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	rules, err := project.LoadIgnore(rootPath)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	for _, p := range cmdFlags.Args() {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(wd, p)
+		}
+		sourcePath, err := filepath.Rel(rootPath, p)
+		if err != nil || sourcePath == ".." || strings.HasPrefix(sourcePath, ".."+string(filepath.Separator)) {
+			c.UI.Error(fmt.Sprintf("%s is outside repository %s", p, rootPath))
+			return 1
+		}
+		sourcePath = filepath.ToSlash(sourcePath)
+
+		var (
+			rule project.IgnoreRule
+			ok   bool
+		)
+		if fi, err := os.Stat(p); err == nil && fi.IsDir() {
+			rule, ok = rules.MatchDir(sourcePath)
+		} else {
+			rule, ok = rules.Match(sourcePath)
+		}
+		switch {
+		case !ok:
+			c.UI.Output(fmt.Sprintf("%s not ignored", sourcePath))
+		case rule.Negate:
+			c.UI.Output(fmt.Sprintf("%s not ignored by %s", sourcePath, rule))
+		default:
+			c.UI.Output(fmt.Sprintf("%s ignored by %s", sourcePath, rule))
+		}
+	}
+	return 0
+}
+
+// Synopsis return help for check-ignore command
+func (c CheckIgnoreCmd) Synopsis() string {
+	return "Show if paths are ignored for time tracking"
+}
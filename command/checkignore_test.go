@@ -0,0 +1,64 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+func TestCheckIgnore(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	repo.Seed()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile(project.IgnoreFile, "", "vendor/\n*.pb.go\n!keep.pb.go\n")
+
+	ui := new(cli.MockUi)
+	c := CheckIgnoreCmd{UI: ui}
+
+	util.CheckFatal(t, os.MkdirAll(filepath.Join(repo.Workdir(), "vendor"), 0700))
+
+	args := []string{"vendor/errors/errors.go", "api/service.pb.go", "api/keep.pb.go", "main.go", "vendor", "..env"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm check-ignore(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{
+		"vendor/errors/errors.go ignored by .gtmignore:1:vendor/",
+		"api/service.pb.go ignored by .gtmignore:2:*.pb.go",
+		"api/keep.pb.go not ignored by .gtmignore:3:!keep.pb.go",
+		"main.go not ignored",
+		"vendor ignored by .gtmignore:1:vendor/",
+		"..env not ignored",
+	} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm check-ignore(%+v), want %s got %s", args, want, ui.OutputWriter.String())
+		}
+	}
+}
+
+func TestCheckIgnoreNoPath(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := CheckIgnoreCmd{UI: ui}
+
+	args := []string{}
+	rc := c.Run(args)
+
+	if rc != 1 {
+		t.Errorf("gtm check-ignore(%+v), want 1 got %d", args, rc)
+	}
+}
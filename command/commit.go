@@ -8,6 +8,7 @@ import (
 	"flag"
 	"strings"
 
+	"github.com/DEVELOPEST/gtm-core/config"
 	"github.com/DEVELOPEST/gtm-core/metric"
 	"github.com/mitchellh/cli"
 )
@@ -32,6 +33,8 @@ Usage: gtm commit [options]
 Options:
 
   -yes                       Save time data without asking for confirmation.
+  -resolution=""             Timeline resolution of the note [hour|minute], minute keeps the time spent by minute
+                             for gtm show (default hour or notes.resolution in ~/.config/gtm/config.json)
 `
 	return strings.TrimSpace(helpText)
 }
@@ -40,13 +43,29 @@ Options:
 func (c CommitCmd) Run(args []string) int {
 
 	var yes bool
+	var resolution string
 	cmdFlags := flag.NewFlagSet("commit", flag.ContinueOnError)
 	cmdFlags.BoolVar(&yes, "yes", false, "")
+	cmdFlags.StringVar(&resolution, "resolution", "", "")
 	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		return 1
 	}
 
+	if resolution == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		resolution = cfg.Notes.Resolution
+	}
+	res, err := metric.ParseResolution(resolution)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
 	confirm := yes
 	if !confirm {
 		response, err := c.UI.Ask("Save time for last commit (y/n)?")
@@ -57,7 +76,7 @@ func (c CommitCmd) Run(args []string) int {
 	}
 
 	if confirm {
-		if _, err := metric.Process(false); err != nil {
+		if _, err := metric.Process(false, res); err != nil {
 			c.UI.Error(err.Error())
 			return 1
 		}
@@ -46,3 +46,18 @@ func TestCommitInvalidOption(t *testing.T) {
 		t.Errorf("gtm commit(%+v), want 'Usage:'  got %d, %s", args, rc, ui.OutputWriter.String())
 	}
 }
+
+func TestCommitInvalidResolution(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := CommitCmd{UI: ui}
+
+	args := []string{"-yes", "-resolution=second"}
+	rc := c.Run(args)
+
+	if rc != 1 {
+		t.Errorf("gtm commit(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter)
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Resolution second not valid") {
+		t.Errorf("gtm commit(%+v), want 'Resolution second not valid' got %s", args, ui.ErrorWriter.String())
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/report"
+	"github.com/DEVELOPEST/gtm-core/scm"
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+// LogCmd contains method for log command
+type LogCmd struct {
+	UI cli.Ui
+}
+
+// NewLog returns a new LogCmd struct
+func NewLog() (cli.Command, error) {
+	return LogCmd{}, nil
+}
+
+// Help returns help for log command
+func (c LogCmd) Help() string {
+	helpText := `
+Usage: gtm log [options]
+
+  Show a line per commit with git log style placeholders, i.e. gtm log -pretty '%h %tT %tb %s'
+
+Options:
+
+  -pretty="%h %tT %s"        Format of each commit, the placeholders are
+                             %H commit hash, %h abbreviated commit hash, %s subject, %b body,
+                             %an author name, %ae author email, %ad author date, %n newline, %% a percent sign,
+                             %tT total time, %ts total seconds, %tF file with the most time, %tb branch, %tp project
+  -tz=""                     Time zone of dates and day boundaries, an IANA name, i.e. -tz Europe/Tallinn,
+                             or author for the time zone each commit's time was recorded in (default local time zone)
+  -terminal-off=false        Exclude time spent in terminal (Terminal plug-in is required)
+  -app-off=false             Exclude time spent in apps
+
+  Commit Limiting:
+
+  -n int=0                   Limit output, 0 is no limit
+  -from-date=yyyy-mm-dd      Show commits starting from this date
+  -to-date=yyyy-mm-dd        Show commits thru the end of this date
+  -author=""                 Show commits which contain author name or email substring, resolved with .mailmap
+  -message=""                Show commits which contain message substring
+  -branch=""                 Show commits recorded on branches matching glob pattern, i.e. -branch 'feature/*'
+  -revisions=""              Show commits reachable from git revisions instead of HEAD, i.e. -revisions 'main..feature'
+  -since-tag=""              Show commits after a tag, i.e. -since-tag v1.0
+  -until-tag=""              Show commits thru a tag instead of HEAD, i.e. -since-tag v1.0 -until-tag v1.1
+  -today=false               Show commits for today
+  -yesterday=false           Show commits for yesterday
+  -this-week=false           Show commits for this week
+  -last-week=false           Show commits for last week
+  -this-month=false          Show commits for this month
+  -last-month=false          Show commits for last month
+  -this-year=false           Show commits for this year
+  -last-year=false           Show commits for last year
+
+  Multi-Project Reporting:
+
+  -tags=""                   Project tags to show commits for, i.e --tags tag1,tag2 or a query, i.e. --tags 'client-x AND NOT internal'
+  -all=false                 Show commits for all projects
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Run executes log command with args
+func (c LogCmd) Run(args []string) int {
+	var limit int
+	var terminalOff, appOff, all bool
+	var today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear, lastYear bool
+	var fromDate, toDate, message, author, branch, revisions, sinceTag, untilTag, tags, pretty, tz string
+	cmdFlags := flag.NewFlagSet("log", flag.ContinueOnError)
+	cmdFlags.StringVar(&pretty, "pretty", report.DefaultPretty, "")
+	cmdFlags.StringVar(&tz, "tz", "", "")
+	cmdFlags.BoolVar(&terminalOff, "terminal-off", false, "")
+	cmdFlags.BoolVar(&appOff, "app-off", false, "")
+	cmdFlags.IntVar(&limit, "n", 0, "")
+	cmdFlags.StringVar(&fromDate, "from-date", "", "")
+	cmdFlags.StringVar(&toDate, "to-date", "", "")
+	cmdFlags.BoolVar(&today, "today", false, "")
+	cmdFlags.BoolVar(&yesterday, "yesterday", false, "")
+	cmdFlags.BoolVar(&thisWeek, "this-week", false, "")
+	cmdFlags.BoolVar(&lastWeek, "last-week", false, "")
+	cmdFlags.BoolVar(&thisMonth, "this-month", false, "")
+	cmdFlags.BoolVar(&lastMonth, "last-month", false, "")
+	cmdFlags.BoolVar(&thisYear, "this-year", false, "")
+	cmdFlags.BoolVar(&lastYear, "last-year", false, "")
+	cmdFlags.StringVar(&author, "author", "", "")
+	cmdFlags.StringVar(&message, "message", "", "")
+	cmdFlags.StringVar(&branch, "branch", "", "")
+	cmdFlags.StringVar(&revisions, "revisions", "", "")
+	cmdFlags.StringVar(&sinceTag, "since-tag", "", "")
+	cmdFlags.StringVar(&untilTag, "until-tag", "", "")
+	cmdFlags.StringVar(&tags, "tags", "", "")
+	cmdFlags.BoolVar(&all, "all", false, "")
+	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	location, err := timeZone(tz)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("log --tz=%s not valid, %s\n", tz, err))
+		return 1
+	}
+	if location != nil {
+		saveNow := util.Now
+		defer func() { util.Now = saveNow }()
+		util.Now = func() time.Time { return saveNow().In(location) }
+	}
+
+	index, err := project.NewIndex()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var tagQuery project.TagQuery
+	if tags != "" {
+		if tagQuery, err = project.ParseTagQuery(tags); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+	}
+	rc := 0
+	projects, err := index.Get(tagQuery, all)
+	if _, ok := err.(project.TagsError); ok {
+		// projects with unreadable tags are reported and left out
+		c.UI.Error(err.Error())
+		rc = 1
+	} else if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	limiter, err := scm.NewCommitLimiter(
+		limit, fromDate, toDate, author, message, branch, project.NoteNameSpace, nil, strings.Fields(revisions),
+		sinceTag, untilTag,
+		today, yesterday, thisWeek, lastWeek,
+		thisMonth, lastMonth, thisYear, lastYear)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if location != nil {
+		limiter.DateRange = limiter.DateRange.In(location)
+	}
+
+	// read projects concurrently, a project with an error is reported and left out
+	results := make([]report.ProjectCommits, len(projects))
+	errs := make([]error, len(projects))
+	util.Parallel(len(projects), func(i int) {
+		results[i].Path = projects[i]
+		results[i].Commits, errs[i] = scm.CommitIDs(limiter, projects[i])
+	})
+
+	projCommits := []report.ProjectCommits{}
+	for i := range results {
+		if errs[i] != nil {
+			c.UI.Error(projectError(projects[i], errs[i], len(projects)))
+			rc = 1
+			continue
+		}
+		projCommits = append(projCommits, results[i])
+	}
+
+	out, err := report.Log(projCommits, report.OutputOptions{
+		TerminalOff: terminalOff,
+		AppOff:      appOff,
+		Limit:       limiter.Max,
+		Location:    location,
+		AuthorTZ:    tz == report.TZAuthor,
+		Pretty:      pretty})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if out != "" {
+		c.UI.Output(out)
+	}
+	return rc
+}
+
+// Synopsis return help for log command
+func (c LogCmd) Synopsis() string {
+	return "Show a line per commit with time data in a git log style format"
+}
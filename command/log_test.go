@@ -0,0 +1,72 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+func TestLog(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	commitID := repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	args := []string{"-pretty", "format:%H|%h|%tT|%ts|%tF|%tb|%tp|100%%|%x"}
+	rc := (LogCmd{UI: ui}).Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm log(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	want := strings.Join([]string{
+		commitID.String(), commitID.String()[:7], "3m  0s", "180", "event/event.go", "master",
+		filepath.Base(repo.Workdir()), "100%", "%x"}, "|") + "\n"
+	if got := ui.OutputWriter.String(); got != want {
+		t.Errorf("gtm log(%+v), want %q got %q, %s", args, want, got, ui.ErrorWriter.String())
+	}
+
+	// commit limiting filters the commits
+	ui = new(cli.MockUi)
+	args = []string{"-author", "nobody", "-pretty", "%h"}
+	if rc := (LogCmd{UI: ui}).Run(args); rc != 0 || ui.OutputWriter.String() != "" {
+		t.Errorf("gtm log(%+v), want 0 and no output got %d %q, %s", args, rc, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+}
+
+func TestLogInvalidOption(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := LogCmd{UI: ui}
+
+	args := []string{"-invalid"}
+	rc := c.Run(args)
+
+	if rc != 1 {
+		t.Errorf("gtm log(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Usage:") {
+		t.Errorf("gtm log(%+v), want 'Usage:'  got %d, %s", args, rc, ui.OutputWriter.String())
+	}
+}
@@ -99,7 +99,7 @@ func (c RecordCmd) Run(args []string) int {
 		return 0
 	}
 
-	if err := event.Record(fileToRecord); err != nil && !(err == project.ErrNotInitialized || err == project.ErrFileNotFound) {
+	if err := event.Record(fileToRecord); err != nil && !(err == project.ErrNotInitialized || err == project.ErrFileNotFound || err == project.ErrFileIgnored) {
 		return 1
 	} else if err == nil && status {
 		var (
@@ -126,7 +126,7 @@ func (c RecordCmd) Run(args []string) int {
 			return 1
 		}
 
-		if commitNote, err = metric.Process(true); err != nil {
+		if commitNote, err = metric.Process(true, metric.ResolutionHour); err != nil {
 			c.UI.Error(err.Error())
 			return 1
 		}
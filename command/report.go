@@ -9,14 +9,18 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/DEVELOPEST/gtm-core/config"
+	"github.com/DEVELOPEST/gtm-core/metric"
 	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/report"
 	"github.com/DEVELOPEST/gtm-core/scm"
 	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/DEVELOPEST/gtm-core/workhours"
 	"github.com/briandowns/spinner"
 	isatty "github.com/mattn/go-isatty"
 	"github.com/mitchellh/cli"
@@ -43,8 +47,27 @@ Options:
 
   Report Formats:
 
-  -format=commits            Specify report format [summary|project|commits|files|timeline-hours|timeline-commits] (default commits)
+  -format=commits            Specify report format [summary|project|commits|files|tree|languages|branches|authors|issues|timesheet|invoice|hotspots|overtime|timeline-hours|timeline-commits|
+                             timeline-days|timeline-weeks|timeline-months|releases] (default commits), timeline-days is a calendar heatmap,
+                             releases attributes commits to the first tag containing them
+  -template=""               Render the report with a user-defined text/template file, or a named template
+                             in ~/.config/gtm/templates/, i.e. -template standup for standup.tmpl (see docs/templates.md)
   -full-message=false        Include full commit message
+  -depth=0                   Directory levels to show in the tree report, 0 is no limit
+  -grid=week                 Timesheet grid [week|month], week has a column per day and month a column per week
+  -week-start=""             First day of the week for timesheets and timelines, i.e. monday (default sunday)
+  -rounding=""               Round timesheet entries per project and day [none|nearest|up] (default none)
+  -increment=0               Timesheet rounding increment in minutes (default 15)
+  -compare=false             Compare the selected period, i.e. -this-week, to the previous period by project, file and author,
+                             it can't be combined with -format or -n
+  -periods=4                 Number of periods shown in the trend when comparing periods
+  -output=text               Invoice output [text|csv|json], invoice periods are set with -grid
+  -include-pending=false     Include time not yet committed as an uncommitted entry when the period includes today,
+                             the git user and current branch must match -author, -team and -branch, -message and -revisions exclude it
+  -within-work-hours=false   Only include time spent within working hours
+  -outside-work-hours=false  Only include time spent outside working hours, i.e. evenings, weekends and holidays
+  -tz=""                     Time zone of dates, timelines, day boundaries and working hours, an IANA name, i.e. -tz Europe/Tallinn,
+                             or author for the time zone each commit's time was recorded in (default local time zone)
   -terminal-off=false        Exclude time spent in terminal (Terminal plug-in is required)
   -app-off=false             Exclude time spent in apps
   -force-color=false         Always output color even if no terminal is detected, i.e 'gtm report -color | less -R'
@@ -55,9 +78,20 @@ Options:
   -n int=0                   Limit output, 0 is no limit
   -from-date=yyyy-mm-dd      Show commits starting from this date
   -to-date=yyyy-mm-dd        Show commits thru the end of this date
-  -author=""                 Show commits which contain author substring
+  -author=""                 Show commits which contain author name or email substring, resolved with .mailmap
+  -team=""                   Show commits by members of a team in the team mapping file
+  -teams-file=""             Team mapping file, JSON of team names to member emails (default ~/.config/gtm/teams.json)
   -message=""                Show commits which contain message substring
+  -branch=""                 Show commits recorded on branches matching glob pattern, i.e. -branch 'feature/*'
+  -issue=""                  Show commits which reference an issue key in their subject, message or branch, i.e. -issue PROJ-123
   -subdir=""                 Show commits that are in subdirectory
+  -include=""                Only include time for files matching a glob, repeatable, i.e. -include 'src/**' -include '*.go'
+  -exclude=""                Exclude time for files matching a glob, repeatable, i.e. -exclude 'vendor/**' -exclude '*.pb.go'
+                             ** matches any number of directories, a glob without a slash matches file names in any directory
+  -revisions=""              Show commits reachable from git revisions instead of HEAD, i.e. -revisions 'main..feature',
+                             -revisions '--branches ^v1.0' or -revisions=--all for all branches, remote branches and tags
+  -since-tag=""              Show commits after a tag, i.e. -since-tag v1.0
+  -until-tag=""              Show commits thru a tag instead of HEAD, i.e. -since-tag v1.0 -until-tag v1.1
   -today=false               Show commits for today
   -yesterday=false           Show commits for yesterday
   -this-week=false           Show commits for this week
@@ -69,24 +103,62 @@ Options:
 
   Multi-Project Reporting:
 
-  -tags=""                   Project tags to report on, i.e --tags tag1,tag2
+  -tags=""                   Project tags to report on, i.e --tags tag1,tag2 or a query, i.e. --tags 'client-x AND NOT internal'
+                             or --tags '(a OR b) AND go', operators are NOT, AND and OR, a comma is the same as OR
   -all=false                 Show commits for all projects
+
+  Configuration:
+
+  The languages report can be extended in ~/.config/gtm/config.json, i.e.
+  {"languages": {"Migrations": ["db/migrations/*.sql"], "Templ": [".templ"]}}
+
+  Timesheet defaults can be set in config.json, i.e.
+  {"timesheet": {"weekStart": "monday", "rounding": "up", "increment": 15}}
+
+  Invoice rates are hourly, the project rate is used first then tag, author and default rates.
+  Time is rounded up to the billable increment in minutes per project, author and day, i.e.
+  {"billing": {"currency": "EUR", "increment": 15, "default": 80,
+               "projects": {"gtm": 120}, "tags": {"acme": 100}, "authors": {"jane@example.com": 95}}}
+
+  Working hours for -format overtime and the work hours filters are set in config.json, the defaults are
+  monday to friday 09:00 to 17:00 local time, holidays is a file with a yyyy-mm-dd date per line, i.e.
+  {"workHours": {"days": ["monday", "tuesday", "wednesday", "thursday", "friday"], "start": "09:00",
+                 "end": "17:00", "timezone": "Europe/Tallinn", "holidays": "holidays.txt"}}
+
+  Issue keys are found with the regular expressions in config.json, the default patterns match
+  keys like PROJ-123 and #456, i.e.
+  {"issues": {"patterns": ["\\b[A-Z][A-Z0-9]+-[0-9]+\\b", "\\bGH-[0-9]+\\b"]}}
 `
 	return strings.TrimSpace(helpText)
 }
 
 // Run executes report command with args
 func (c ReportCmd) Run(args []string) int {
-	var limit int
-	var color, terminalOff, appOff, fullMessage, testing bool
+	var limit, depth, increment, periods int
+	var color, terminalOff, appOff, fullMessage, testing, compare, includePending, withinWorkHours, outsideWorkHours bool
 	var today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth, thisYear, lastYear, all bool
-	var fromDate, toDate, message, author, subdir, tags, format string
+	var fromDate, toDate, message, author, branch, issue, team, teamsFile, subdir, revisions, sinceTag, untilTag, tags, format string
+	var grid, weekStart, rounding, output, tmpl, tz string
+	var include, exclude globsFlag
 	cmdFlags := flag.NewFlagSet("report", flag.ContinueOnError)
 	cmdFlags.BoolVar(&color, "force-color", false, "")
 	cmdFlags.BoolVar(&terminalOff, "terminal-off", false, "")
 	cmdFlags.BoolVar(&appOff, "app-off", false, "")
+	cmdFlags.BoolVar(&includePending, "include-pending", false, "")
+	cmdFlags.BoolVar(&withinWorkHours, "within-work-hours", false, "")
+	cmdFlags.BoolVar(&outsideWorkHours, "outside-work-hours", false, "")
+	cmdFlags.StringVar(&tz, "tz", "", "")
 	cmdFlags.StringVar(&format, "format", "commits", "")
 	cmdFlags.IntVar(&limit, "n", 0, "")
+	cmdFlags.IntVar(&depth, "depth", 0, "")
+	cmdFlags.StringVar(&grid, "grid", report.TimesheetWeek, "")
+	cmdFlags.StringVar(&weekStart, "week-start", "", "")
+	cmdFlags.StringVar(&rounding, "rounding", "", "")
+	cmdFlags.IntVar(&increment, "increment", 0, "")
+	cmdFlags.StringVar(&output, "output", report.OutputText, "")
+	cmdFlags.BoolVar(&compare, "compare", false, "")
+	cmdFlags.StringVar(&tmpl, "template", "", "")
+	cmdFlags.IntVar(&periods, "periods", 4, "")
 	cmdFlags.BoolVar(&fullMessage, "full-message", false, "")
 	cmdFlags.StringVar(&fromDate, "from-date", "", "")
 	cmdFlags.StringVar(&toDate, "to-date", "", "")
@@ -99,8 +171,17 @@ func (c ReportCmd) Run(args []string) int {
 	cmdFlags.BoolVar(&thisYear, "this-year", false, "")
 	cmdFlags.BoolVar(&lastYear, "last-year", false, "")
 	cmdFlags.StringVar(&author, "author", "", "")
+	cmdFlags.StringVar(&team, "team", "", "")
+	cmdFlags.StringVar(&teamsFile, "teams-file", "", "")
 	cmdFlags.StringVar(&message, "message", "", "")
+	cmdFlags.StringVar(&branch, "branch", "", "")
+	cmdFlags.StringVar(&issue, "issue", "", "")
 	cmdFlags.StringVar(&subdir, "subdir", "", "")
+	cmdFlags.Var(&include, "include", "")
+	cmdFlags.Var(&exclude, "exclude", "")
+	cmdFlags.StringVar(&revisions, "revisions", "", "")
+	cmdFlags.StringVar(&sinceTag, "since-tag", "", "")
+	cmdFlags.StringVar(&untilTag, "until-tag", "", "")
 	cmdFlags.StringVar(&tags, "tags", "", "")
 	cmdFlags.BoolVar(&all, "all", false, "")
 	cmdFlags.BoolVar(&testing, "testing", false, "")
@@ -109,17 +190,135 @@ func (c ReportCmd) Run(args []string) int {
 		return 1
 	}
 
-	if !util.StringInSlice([]string{"summary", "commits", "timeline-hours", "files", "timeline-commits", "project"}, format) {
+	if !util.StringInSlice([]string{"summary", "commits", "timeline-hours", "files", "timeline-commits", "project", "branches", "authors", "tree", "languages", "issues", "timesheet", "invoice", "hotspots", "overtime", "timeline-days", "timeline-weeks", "timeline-months", "releases"}, format) {
 		c.UI.Error(fmt.Sprintf("report --format=%s not valid\n", format))
 		return 1
 	}
 
+	if compare {
+		formatSet := false
+		cmdFlags.Visit(func(f *flag.Flag) { formatSet = formatSet || f.Name == "format" })
+		if formatSet {
+			c.UI.Error("report --compare and --format are mutually exclusive\n")
+			return 1
+		}
+		// the limit would apply to all periods together
+		if limit > 0 {
+			c.UI.Error("report --compare and -n are mutually exclusive\n")
+			return 1
+		}
+	}
+
+	if depth < 0 {
+		c.UI.Error(fmt.Sprintf("report --depth=%d not valid\n", depth))
+		return 1
+	}
+
 	var (
 		commits []string
 		out     string
 		err     error
+		emails  []string
+		ranges  []util.DateRange
+		// pendingRange is the period that must include now to report pending time
+		pendingRange util.DateRange
+		// limiter limits the commits and the pending time of the projects
+		limiter scm.CommitLimiter
 	)
 
+	cfg, err := config.Load()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if !util.StringInSlice([]string{report.TimesheetWeek, report.TimesheetMonth}, grid) {
+		c.UI.Error(fmt.Sprintf("report --grid=%s not valid\n", grid))
+		return 1
+	}
+
+	if periods < 2 {
+		c.UI.Error(fmt.Sprintf("report --periods=%d not valid, at least 2 periods are required\n", periods))
+		return 1
+	}
+
+	if !util.StringInSlice([]string{report.OutputText, report.OutputCSV, report.OutputJSON}, output) {
+		c.UI.Error(fmt.Sprintf("report --output=%s not valid\n", output))
+		return 1
+	}
+
+	if weekStart == "" {
+		weekStart = cfg.Timesheet.WeekStart
+	}
+	firstDay := time.Sunday
+	if weekStart != "" {
+		if firstDay, err = util.ParseWeekday(weekStart); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+	}
+
+	if rounding == "" {
+		rounding = cfg.Timesheet.Rounding
+	}
+	if increment == 0 {
+		increment = cfg.Timesheet.Increment
+	}
+	roundTo, err := report.NewRounding(rounding, increment)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if withinWorkHours && outsideWorkHours {
+		c.UI.Error("report --within-work-hours and --outside-work-hours are mutually exclusive\n")
+		return 1
+	}
+	workHoursFilter := ""
+	switch {
+	case withinWorkHours:
+		workHoursFilter = report.WithinWorkHours
+	case outsideWorkHours:
+		workHoursFilter = report.OutsideWorkHours
+	}
+	var calendar workhours.Calendar
+	if workHoursFilter != "" || format == "overtime" {
+		if calendar, err = workhours.NewCalendar(cfg.WorkHours); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+	}
+
+	// with a time zone day boundaries of date ranges and today are in the zone, author zones keep the local day boundaries
+	location, err := timeZone(tz)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("report --tz=%s not valid, %s\n", tz, err))
+		return 1
+	}
+	if location != nil {
+		saveNow := util.Now
+		defer func() { util.Now = saveNow }()
+		util.Now = func() time.Time { return saveNow().In(location) }
+	}
+
+	issues, err := report.NewIssueExtractor(cfg.Issues.Patterns)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	teams, err := project.LoadTeams(teamsFile)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if team != "" {
+		if emails, err = teams.Members(team); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+	}
+
 	const invalidSHA1 = "\nNot a valid commit SHA-1 %s\n"
 
 	// if running from within a MINGW console isatty detection does not work
@@ -128,7 +327,11 @@ func (c ReportCmd) Run(args []string) int {
 
 	sha1Regex := regexp.MustCompile(`\A([0-9a-f]{40})\z`)
 
-	var projCommits []report.ProjectCommits
+	var (
+		projCommits []report.ProjectCommits
+		// projectErrors is true if a project could not be read, the others are still reported
+		projectErrors bool
+	)
 
 	switch {
 	case !testing && !isMinGW && !isatty.IsTerminal(os.Stdin.Fd()):
@@ -181,18 +384,26 @@ func (c ReportCmd) Run(args []string) int {
 			return 1
 		}
 
-		var tagList []string
+		var tagQuery project.TagQuery
 		if tags != "" {
-			tagList = util.Map(strings.Split(tags, ","), strings.TrimSpace)
+			if tagQuery, err = project.ParseTagQuery(tags); err != nil {
+				c.UI.Error(err.Error())
+				return 1
+			}
 		}
-		projects, err := index.Get(tagList, all)
-		if err != nil {
+		projects, err := index.Get(tagQuery, all)
+		if _, ok := err.(project.TagsError); ok {
+			// projects with unreadable tags are reported and left out
+			c.UI.Error(err.Error())
+			projectErrors = true
+		} else if err != nil {
 			c.UI.Error(err.Error())
 			return 1
 		}
 
-		limiter, err := scm.NewCommitLimiter(
-			limit, fromDate, toDate, author, message,
+		limiter, err = scm.NewCommitLimiter(
+			limit, fromDate, toDate, author, message, branch, project.NoteNameSpace, emails, strings.Fields(revisions),
+			sinceTag, untilTag,
 			today, yesterday, thisWeek, lastWeek,
 			thisMonth, lastMonth, thisYear, lastYear)
 
@@ -202,29 +413,117 @@ func (c ReportCmd) Run(args []string) int {
 		}
 
 		limit = limiter.Max
+		if location != nil {
+			limiter.DateRange = limiter.DateRange.In(location)
+		}
+		pendingRange = limiter.DateRange
+
+		if compare {
+			switch {
+			case today, yesterday:
+				ranges = previousPeriods(limiter.DateRange, periods, util.TodayRange, 0, 0, 1)
+			case thisWeek, lastWeek:
+				ranges = previousPeriods(limiter.DateRange, periods, util.ThisWeekRange, 0, 0, 7)
+			case thisMonth, lastMonth:
+				ranges = previousPeriods(limiter.DateRange, periods, util.ThisMonthRange, 0, 1, 0)
+			case thisYear, lastYear:
+				ranges = previousPeriods(limiter.DateRange, periods, util.ThisYearRange, 1, 0, 0)
+			}
+			if len(ranges) > 0 {
+				// read the commits of all periods, they are split into periods when reporting
+				limiter.DateRange = util.DateRange{Start: ranges[len(ranges)-1].Start, End: ranges[0].End}
+			}
+		}
 
-		for _, p := range projects {
-			commits, err = scm.CommitIDs(limiter, p)
+		// read projects concurrently, a project with an error is reported and left out
+		results := make([]report.ProjectCommits, len(projects))
+		errs := make([]error, len(projects))
+		util.Parallel(len(projects), func(i int) {
+			results[i].Path = projects[i]
+			results[i].Commits, errs[i] = scm.CommitIDs(limiter, projects[i])
+		})
+		for i := range results {
+			if errs[i] != nil {
+				c.UI.Error(projectError(projects[i], errs[i], len(projects)))
+				projectErrors = true
+				continue
+			}
+			projCommits = append(projCommits, results[i])
+		}
+	}
+
+	if includePending && (!pendingRange.IsSet() || pendingRange.Within(util.Now())) {
+		errs := make([]error, len(projCommits))
+		util.Parallel(len(projCommits), func(i int) {
+			name, email := scm.GitUser(projCommits[i].Path)
+			if !limiter.MatchUncommitted(name, email, scm.CurrentBranch(projCommits[i].Path)) {
+				return
+			}
+			pending, err := metric.Process(true, metric.ResolutionHour, projCommits[i].Path)
 			if err != nil {
-				c.UI.Error(err.Error())
-				return 1
+				errs[i] = err
+				return
+			}
+			projCommits[i].Pending = &pending
+		})
+		for i, err := range errs {
+			if err != nil {
+				c.UI.Error(projectError(projCommits[i].Path, err, len(projCommits)))
+				projectErrors = true
 			}
-			projCommits = append(projCommits, report.ProjectCommits{Path: p, Commits: commits})
 		}
 	}
 
+	if compare {
+		if len(ranges) == 0 {
+			c.UI.Error("report --compare requires a period, i.e. -this-week or -last-month\n")
+			return 1
+		}
+		format = "compare"
+	}
+
+	var tmplText string
+	if tmpl != "" {
+		if tmplText, err = config.LoadTemplate(tmpl); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		format = "template"
+	}
+
 	options := report.OutputOptions{
 		FullMessage: fullMessage,
 		TerminalOff: terminalOff,
 		AppOff:      appOff,
 		Color:       color,
 		Limit:       limit,
-		Subdir:      subdir}
+		Depth:       depth,
+		Subdir:      subdir,
+		Include:     include,
+		Exclude:     exclude,
+		Issue:       issue,
+		Issues:      issues,
+		Timesheet:   report.TimesheetOptions{Grid: grid, WeekStart: firstDay, Rounding: roundTo},
+		Rates:       cfg.Billing,
+		Periods:     ranges,
+		Template:    tmplText,
+		Output:      output,
+		Teams:       teams,
+		Languages:   cfg.Languages,
+
+		WorkHours:       calendar,
+		WorkHoursFilter: workHoursFilter,
+		Location:        location,
+		AuthorTZ:        tz == report.TZAuthor}
 
 	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 	s.Start()
 
 	switch format {
+	case "compare":
+		out, err = report.Compare(projCommits, options)
+	case "template":
+		out, err = report.Custom(projCommits, options)
 	case "project":
 		out, err = report.ProjectSummary(projCommits, options)
 	case "summary":
@@ -233,10 +532,36 @@ func (c ReportCmd) Run(args []string) int {
 		out, err = report.Commits(projCommits, options)
 	case "files":
 		out, err = report.Files(projCommits, options)
+	case "tree":
+		out, err = report.Tree(projCommits, options)
+	case "languages":
+		out, err = report.Languages(projCommits, options)
+	case "branches":
+		out, err = report.Branches(projCommits, options)
+	case "authors":
+		out, err = report.Authors(projCommits, options)
+	case "issues":
+		out, err = report.Issues(projCommits, options)
+	case "timesheet":
+		out, err = report.Timesheet(projCommits, options)
+	case "invoice":
+		out, err = report.Invoice(projCommits, options)
+	case "hotspots":
+		out, err = report.Hotspots(projCommits, options)
+	case "overtime":
+		out, err = report.Overtime(projCommits, options)
 	case "timeline-hours":
 		out, err = report.Timeline(projCommits, options)
 	case "timeline-commits":
 		out, err = report.TimelineCommits(projCommits, options)
+	case "timeline-days":
+		out, err = report.TimelineDays(projCommits, options)
+	case "timeline-weeks":
+		out, err = report.TimelineWeeks(projCommits, options)
+	case "timeline-months":
+		out, err = report.TimelineMonths(projCommits, options)
+	case "releases":
+		out, err = report.Releases(projCommits, options)
 	}
 
 	s.Stop()
@@ -247,9 +572,60 @@ func (c ReportCmd) Run(args []string) int {
 	}
 	c.UI.Output(out)
 
+	if projectErrors {
+		return 1
+	}
 	return 0
 }
 
+// timeZone returns the location of a -tz option, nil for the local time zone and for author time zones
+func timeZone(tz string) (*time.Location, error) {
+	if tz == "" || tz == report.TZAuthor {
+		return nil, nil
+	}
+	return time.LoadLocation(tz)
+}
+
+// previousPeriods returns the current period followed by the periods before it, up to n periods,
+// the earlier periods are the period's range helper at the start of the current period stepped back
+// by years, months and days
+func previousPeriods(current util.DateRange, n int, period func() util.DateRange, years, months, days int) []util.DateRange {
+	saveNow := util.Now
+	defer func() { util.Now = saveNow }()
+
+	ranges := []util.DateRange{current}
+	for i := 1; i < n; i++ {
+		start := current.Start.AddDate(-years*i, -months*i, -days*i)
+		util.Now = func() time.Time { return start }
+		ranges = append(ranges, period())
+	}
+	return ranges
+}
+
+// projectError returns the error message prefixed with the project when there are several projects
+func projectError(projPath string, err error, projects int) string {
+	if projects == 1 {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s, %s", projPath, err)
+}
+
+// globsFlag is a repeatable flag of file globs
+type globsFlag []string
+
+func (g *globsFlag) String() string {
+	return strings.Join(*g, ",")
+}
+
+// Set validates and appends a glob
+func (g *globsFlag) Set(glob string) error {
+	if _, err := path.Match(glob, ""); err != nil {
+		return fmt.Errorf("invalid glob %s, %s", glob, err)
+	}
+	*g = append(*g, glob)
+	return nil
+}
+
 // Synopsis return help for report command
 func (c ReportCmd) Synopsis() string {
 	return "Display reports for git repositories"
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/util"
@@ -256,6 +257,922 @@ func TestReportFiles(t *testing.T) {
 	}
 }
 
+func TestReportTree(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "tree", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{"3m  0s 100%  event/", "2m 40s  89%    event.go"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	// Limit depth to top level directories
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-format", "tree", "-depth", "1", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "event.go") {
+		t.Errorf("gtm report(%+v), want not 'event.go' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+}
+
+func TestReportLanguages(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("Dockerfile", "", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, "Dockerfile")
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), "Dockerfile"))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "languages", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{"2m 40s  89%  Go", "20s  11%  Dockerfile", "Sun Mar 20"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestReportBranches(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "branches", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	want := "3m  0s 100%    1 master"
+	if !strings.Contains(ui.OutputWriter.String(), want) {
+		t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	// Filter by branch glob
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-branch", "feature/*", "-format", "branches", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "master") {
+		t.Errorf("gtm report(%+v), want not 'master' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-branch", "mast*", "-format", "branches", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if !strings.Contains(ui.OutputWriter.String(), want) {
+		t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+}
+
+func TestReportReleases(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	commit, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage(filepath.Join("event", "event.go"))))
+	util.CheckFatal(t, err)
+	defer commit.Free()
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	// the release candidate and the release tag the same commit, the candidate released it
+	for _, tag := range []string{"v1.0", "v1.0-rc1"} {
+		_, err = repo.Repo().Tags.CreateLightweight(tag, commit, false)
+		util.CheckFatal(t, err)
+	}
+
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458497003.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	next, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage(filepath.Join("event", "event_test.go"))))
+	util.CheckFatal(t, err)
+	defer next.Free()
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+	_, err = repo.Repo().Tags.CreateLightweight("v1.1", next, false)
+	util.CheckFatal(t, err)
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "releases", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{"3m  0s", "1 v1.0-rc1", "1 v1.1", "Rand Om Hacker 100%"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+	if strings.Contains(ui.OutputWriter.String(), "v1.0 ") {
+		t.Errorf("gtm report(%+v), want no commits released by v1.0 got %s", args, ui.OutputWriter.String())
+	}
+
+	// commits after the tag
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-since-tag", "v1.0", "-format", "releases", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "v1.0") || !strings.Contains(ui.OutputWriter.String(), "v1.1") {
+		t.Errorf("gtm report(%+v), want 'v1.1' and not 'v1.0' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-until-tag", "v9.9", "-format", "releases", "-testing=true"}
+	if rc = c.Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d, %s", args, rc, ui.OutputWriter.String())
+	}
+}
+
+func TestReportAuthors(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	repo.SaveFile("teams.json", project.GTMDir, `{"core": ["RANDOM@hacker.com"], "web": ["someone@else.com"]}`)
+	teamsFile := filepath.Join(repo.Workdir(), project.GTMDir, "teams.json")
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "authors", "-teams-file", teamsFile, "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{"3m  0s 100%    1 Rand Om Hacker <random@hacker.com> [core]", "Teams"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	// Limit to team without the author
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-format", "authors", "-team", "web", "-teams-file", teamsFile, "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "Rand Om Hacker") {
+		t.Errorf("gtm report(%+v), want not 'Rand Om Hacker' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	// Unknown team
+	args = []string{"-format", "authors", "-team", "qa", "-teams-file", teamsFile, "-testing=true"}
+	rc = c.Run(args)
+	if rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestReportIssues(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "issues", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	// the test commit does not reference an issue
+	want := "3m  0s    1 (none)"
+	if !strings.Contains(ui.OutputWriter.String(), want) {
+		t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-issue", "PROJ-1", "-format", "issues", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "(none)") {
+		t.Errorf("gtm report(%+v), want not '(none)' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+}
+
+func TestReportTimesheet(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "timesheet", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{"Week of Sun Mar 20 2016", "0:03"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-format", "timesheet", "-week-start", "monday", "-rounding", "up", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	for _, want := range []string{"Week of Mon Mar 14 2016", "0:15"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-format", "timesheet", "-grid", "month", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	want := "March 2016"
+	if !strings.Contains(ui.OutputWriter.String(), want) {
+		t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-format", "timesheet", "-rounding", "sideways", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+}
+
+func TestReportInvoice(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	tests := map[string][]string{
+		"text": {"2016-03", "Rand Om Hacker", "0.05"},
+		"csv":  {"period,project,author,email,seconds", "2016-03,", "random@hacker.com,180,180,0.05"},
+		"json": {`"period": "2016-03"`, `"seconds": 180`},
+	}
+
+	for output, wants := range tests {
+		ui.OutputWriter.Reset()
+		ui.ErrorWriter.Reset()
+		args := []string{"-format", "invoice", "-grid", "month", "-output", output, "-testing=true"}
+		rc := c.Run(args)
+
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+		for _, want := range wants {
+			if !strings.Contains(ui.OutputWriter.String(), want) {
+				t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+			}
+		}
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args := []string{"-format", "invoice", "-output", "xml", "-testing=true"}
+	if rc := c.Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+}
+
+func TestReportCompare(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	// the test commit is dated Wed Mar 06 2013
+	saveNow := util.Now
+	defer func() { util.Now = saveNow }()
+	util.Now = func() time.Time { return time.Date(2013, 3, 7, 12, 0, 0, 0, time.Local) }
+
+	args := []string{"-compare", "-this-week", "-periods", "3", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{
+		"Sun Mar 03 - Sat Mar 09 2013 compared to Sun Feb 24 - Sat Mar 02 2013",
+		"+3m  0s     new  Rand Om Hacker",
+		"+2m 40s     new  event/event.go",
+		"Sun Feb 17 - Sat Feb 23 2013",
+	} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	for _, args := range [][]string{
+		{"-compare", "-testing=true"},
+		{"-compare", "-from-date", "2013-03-03", "-to-date", "2013-03-09", "-testing=true"},
+		{"-compare", "-this-week", "-format", "files", "-testing=true"},
+		{"-compare", "-this-week", "-n", "1", "-testing=true"},
+	} {
+		if rc = c.Run(args); rc != 1 {
+			t.Errorf("gtm report(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestReportHotspots(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "package event\n")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-format", "hotspots", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	for _, want := range []string{
+		"20s       1       +0       -0        0   event/event_test.go",
+		"2m 40s       1       +1       -0       22   event/event.go",
+	} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestReportTemplate(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	repo.SaveFile("standup.tmpl", "",
+		`{{ range .Notes }}{{ .Subject }} {{ FormatDuration .Note.Total }}{{ end }}`+
+			`{{ range .Files }} {{ .Filename }}{{ end }} total {{ FormatDuration .Total }}`)
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-template", filepath.Join(repo.Workdir(), "standup.tmpl"), "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	want := "This is a commit 3m  0s event/event.go event/event_test.go total 3m  0s"
+	if !strings.Contains(ui.OutputWriter.String(), want) {
+		t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-template", "does-not-exist", "-testing=true"}
+	if rc = c.Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+}
+
+func TestReportIncludePending(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	// time spent after the last commit
+	repo.SaveFile("1458497003.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	ui := new(cli.MockUi)
+	c := ReportCmd{UI: ui}
+
+	args := []string{"-include-pending", "-format", "summary", "-testing=true"}
+	rc := c.Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	for _, want := range []string{"uncommitted", "This is a commit"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	// pending time is not reported for periods that do not include today
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+	args = []string{"-include-pending", "-last-year", "-format", "summary", "-testing=true"}
+	rc = c.Run(args)
+	if rc != 0 {
+		t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "uncommitted") {
+		t.Errorf("gtm report(%+v), want not 'uncommitted' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+	}
+
+	// pending time is not reported for commit limits the git user or branch do not match
+	for _, limit := range [][]string{{"-author", "nobody"}, {"-message", "commit"}, {"-branch", "feature/*"}} {
+		ui.OutputWriter.Reset()
+		ui.ErrorWriter.Reset()
+		args = append([]string{"-include-pending", "-format", "summary", "-testing=true"}, limit...)
+		rc = c.Run(args)
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+		if strings.Contains(ui.OutputWriter.String(), "uncommitted") {
+			t.Errorf("gtm report(%+v), want not 'uncommitted' got %s, %s", args, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+}
+
+func TestReportIncludeExclude(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	for _, tc := range []struct {
+		args    []string
+		want    string
+		notWant string
+	}{
+		{[]string{"-format", "files", "-exclude", "*_test.go", "-testing=true"}, "event/event.go", "event/event_test.go"},
+		{[]string{"-format", "files", "-include", "event/**", "-exclude", "event/event.go", "-testing=true"}, "event/event_test.go", "event/event.go"},
+	} {
+		ui := new(cli.MockUi)
+		c := ReportCmd{UI: ui}
+
+		rc := c.Run(tc.args)
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", tc.args, rc, ui.ErrorWriter.String())
+		}
+		if !strings.Contains(ui.OutputWriter.String(), tc.want) {
+			t.Errorf("gtm report(%+v), want %s got %s", tc.args, tc.want, ui.OutputWriter.String())
+		}
+		if strings.Contains(ui.OutputWriter.String(), tc.notWant) {
+			t.Errorf("gtm report(%+v), want no %s got %s", tc.args, tc.notWant, ui.OutputWriter.String())
+		}
+	}
+
+	ui := new(cli.MockUi)
+	args := []string{"-exclude", "[", "-testing=true"}
+	if rc := (ReportCmd{UI: ui}).Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestReportOvertime(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	// the time was spent on a Sunday, outside the default working hours
+	for _, tc := range []struct {
+		args    []string
+		want    string
+		notWant string
+	}{
+		{[]string{"-format", "overtime", "-testing=true"}, "Rand Om Hacker", ""},
+		{[]string{"-format", "overtime", "-testing=true"}, "100%", ""},
+		{[]string{"-format", "files", "-outside-work-hours", "-testing=true"}, "event/event.go", ""},
+		{[]string{"-format", "files", "-within-work-hours", "-testing=true"}, "", "event/event.go"},
+	} {
+		ui := new(cli.MockUi)
+		c := ReportCmd{UI: ui}
+
+		rc := c.Run(tc.args)
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", tc.args, rc, ui.ErrorWriter.String())
+		}
+		if !strings.Contains(ui.OutputWriter.String(), tc.want) {
+			t.Errorf("gtm report(%+v), want %s got %s", tc.args, tc.want, ui.OutputWriter.String())
+		}
+		if tc.notWant != "" && strings.Contains(ui.OutputWriter.String(), tc.notWant) {
+			t.Errorf("gtm report(%+v), want no %s got %s", tc.args, tc.notWant, ui.OutputWriter.String())
+		}
+	}
+
+	ui := new(cli.MockUi)
+	args := []string{"-within-work-hours", "-outside-work-hours", "-testing=true"}
+	if rc := (ReportCmd{UI: ui}).Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestReportWorkHoursTimeZone(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	// Mon Mar 21 2016 12:00 UTC is 21:00 in Tokyo
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("1458561600.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458561610.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"-format", "files", "-within-work-hours", "-tz", "UTC", "-testing=true"}, true},
+		{[]string{"-format", "files", "-within-work-hours", "-tz", "Asia/Tokyo", "-testing=true"}, false},
+		{[]string{"-format", "files", "-outside-work-hours", "-tz", "Asia/Tokyo", "-testing=true"}, true},
+	} {
+		ui := new(cli.MockUi)
+		rc := (ReportCmd{UI: ui}).Run(tc.args)
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", tc.args, rc, ui.ErrorWriter.String())
+		}
+		if got := strings.Contains(ui.OutputWriter.String(), "event/event.go"); got != tc.want {
+			t.Errorf("gtm report(%+v), want event/event.go %t got %s", tc.args, tc.want, ui.OutputWriter.String())
+		}
+	}
+}
+
+func TestReportAuthorTimeZoneDays(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	// record two commits on the same day at UTC+9, each note has its own time zone
+	saveNow := util.Now
+	util.Now = func() time.Time { return time.Now().In(time.FixedZone("+0900", 9*3600)) }
+	defer func() { util.Now = saveNow }()
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go")))
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.Commit(repo.Stage(filepath.Join("event", "event_test.go")))
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	for _, format := range []string{"timesheet", "overtime"} {
+		ui := new(cli.MockUi)
+		args := []string{"-format", format, "-tz", "author", "-testing=true"}
+		rc := (ReportCmd{UI: ui}).Run(args)
+
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+		if got := strings.Count(ui.OutputWriter.String(), "Week of Sun Mar 20 2016"); got != 1 {
+			t.Errorf("gtm report(%+v), want one week got %d, %s", args, got, ui.OutputWriter.String())
+		}
+	}
+}
+
+func TestReportTimeZone(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// record the notes at UTC+9 where the time was spent early on Monday
+	saveNow := util.Now
+	util.Now = func() time.Time { return time.Now().In(time.FixedZone("+0900", 9*3600)) }
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+	util.Now = saveNow
+
+	for _, tc := range []struct {
+		tz   string
+		want string
+	}{
+		{"UTC", "Sun Mar 20"},
+		{"Asia/Tokyo", "Mon Mar 21"},
+		{"author", "Mon Mar 21"},
+	} {
+		ui := new(cli.MockUi)
+		args := []string{"-format", "timeline-hours", "-tz", tc.tz, "-testing=true"}
+		rc := (ReportCmd{UI: ui}).Run(args)
+
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+		if !strings.Contains(ui.OutputWriter.String(), tc.want) {
+			t.Errorf("gtm report(%+v), want %s got %s, %s", args, tc.want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+
+	ui := new(cli.MockUi)
+	args := []string{"-tz", "Mars/Olympus_Mons", "-testing=true"}
+	if rc := (ReportCmd{UI: ui}).Run(args); rc != 1 {
+		t.Errorf("gtm report(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestReportTimelinePeriods(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("event_test.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("event", "event_test.go"))
+	repo.SaveFile("1458496818.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go")))
+
+	// save notes to git repository
+	(CommitCmd{UI: new(cli.MockUi)}).Run([]string{"-yes"})
+
+	for _, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-format", "timeline-days"}, []string{"Mar", "Sun | ▁", "Sat | "}},
+		{[]string{"-format", "timeline-days", "-week-start", "monday"}, []string{"Mon |  \n", "Sun | ▁"}},
+		{[]string{"-format", "timeline-weeks"}, []string{"Su.Mo.Tu.We.Th.Fr.Sa.", "2016-03-20 | ▁▁▁"}},
+		{[]string{"-format", "timeline-weeks", "-week-start", "monday"}, []string{"Mo.Tu.We.Th.Fr.Sa.Su.", "2016-03-14 |                   ▁▁▁"}},
+		{[]string{"-format", "timeline-months"}, []string{"01.02.03.", "Mar 2016"}},
+	} {
+		ui := new(cli.MockUi)
+		args := append(tc.args, "-tz", "UTC", "-testing=true")
+		rc := (ReportCmd{UI: ui}).Run(args)
+
+		if rc != 0 {
+			t.Errorf("gtm report(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(ui.OutputWriter.String(), want) {
+				t.Errorf("gtm report(%+v), want %q got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+			}
+		}
+	}
+}
+
 func TestReportAppsOff(t *testing.T) {
 	repo := util.NewTestRepo(t, false)
 	defer repo.Remove()
@@ -0,0 +1,113 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/report"
+	"github.com/DEVELOPEST/gtm-core/scm"
+	"github.com/mitchellh/cli"
+)
+
+// ShowCmd contains method for show command
+type ShowCmd struct {
+	UI cli.Ui
+}
+
+// NewShow returns a new ShowCmd struct
+func NewShow() (cli.Command, error) {
+	return ShowCmd{}, nil
+}
+
+// Help returns help for show command
+func (c ShowCmd) Help() string {
+	helpText := `
+Usage: gtm show [options] [<commit>]
+
+  Show the time spent by minute in each file of a commit, the commit is a git revision (default HEAD).
+
+  Notes are saved with hour resolution unless the commit was saved with gtm commit -resolution=minute
+  or the resolution is set in ~/.config/gtm/config.json, i.e. {"notes": {"resolution": "minute"}}
+
+Options:
+
+  -tz=""                     Time zone of the timeline, an IANA name, i.e. -tz Europe/Tallinn,
+                             or author for the time zone the time was recorded in (default local time zone)
+  -terminal-off=false        Exclude time spent in terminal (Terminal plug-in is required)
+  -app-off=false             Exclude time spent in apps
+  -force-color=false         Always output color even if no terminal is detected, i.e 'gtm show -color | less -R'
+`
+	return strings.TrimSpace(helpText)
+}
+
+// Run executes show command with args
+func (c ShowCmd) Run(args []string) int {
+	var color, terminalOff, appOff bool
+	var tz string
+	cmdFlags := flag.NewFlagSet("show", flag.ContinueOnError)
+	cmdFlags.BoolVar(&color, "force-color", false, "")
+	cmdFlags.BoolVar(&terminalOff, "terminal-off", false, "")
+	cmdFlags.BoolVar(&appOff, "app-off", false, "")
+	cmdFlags.StringVar(&tz, "tz", "", "")
+	cmdFlags.Usage = func() { c.UI.Output(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(cmdFlags.Args()) > 1 {
+		c.UI.Error("Unable to show, only one commit can be shown")
+		return 1
+	}
+	rev := "HEAD"
+	if len(cmdFlags.Args()) == 1 {
+		rev = cmdFlags.Args()[0]
+	}
+
+	location, err := timeZone(tz)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("show --tz=%s not valid, %s\n", tz, err))
+		return 1
+	}
+
+	projPath, err := scm.GitRepoPath()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	projPath, err = scm.Workdir(projPath)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	commitID, err := scm.RevisionCommitID(rev, projPath)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	out, err := report.Show(
+		[]report.ProjectCommits{{Path: projPath, Commits: []string{commitID}}},
+		report.OutputOptions{
+			TerminalOff: terminalOff,
+			AppOff:      appOff,
+			Color:       color,
+			Location:    location,
+			AuthorTZ:    tz == report.TZAuthor})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Output(out)
+	return 0
+}
+
+// Synopsis return help for show command
+func (c ShowCmd) Synopsis() string {
+	return "Show the time spent by minute in each file of a commit"
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/mitchellh/cli"
+)
+
+func TestShow(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	os.Chdir(repo.Workdir())
+
+	(InitCmd{UI: new(cli.MockUi)}).Run([]string{})
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	repo.Commit(repo.Stage(filepath.Join("event", "event.go")))
+
+	// save notes with minute resolution
+	ui := new(cli.MockUi)
+	args := []string{"-yes", "-resolution=minute"}
+	if rc := (CommitCmd{UI: ui}).Run(args); rc != 0 {
+		t.Fatalf("gtm commit(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	args = []string{"-tz", "UTC", "HEAD"}
+	rc := (ShowCmd{UI: ui}).Run(args)
+
+	if rc != 0 {
+		t.Errorf("gtm show(%+v), want 0 got %d, %s", args, rc, ui.ErrorWriter.String())
+	}
+	for _, want := range []string{"event/event.go", "Sun Mar 20 18:00 | ███ ", "3m  0s"} {
+		if !strings.Contains(ui.OutputWriter.String(), want) {
+			t.Errorf("gtm show(%+v), want %q got %s, %s", args, want, ui.OutputWriter.String(), ui.ErrorWriter.String())
+		}
+	}
+	if strings.Contains(ui.OutputWriter.String(), "recorded by hour") {
+		t.Errorf("gtm show(%+v), want minute resolution got %s", args, ui.OutputWriter.String())
+	}
+
+	ui = new(cli.MockUi)
+	args = []string{"not-a-revision"}
+	if rc := (ShowCmd{UI: ui}).Run(args); rc != 1 {
+		t.Errorf("gtm show(%+v), want 1 got %d", args, rc)
+	}
+}
+
+func TestShowInvalidOption(t *testing.T) {
+	ui := new(cli.MockUi)
+	c := ShowCmd{UI: ui}
+
+	args := []string{"-invalid"}
+	rc := c.Run(args)
+
+	if rc != 1 {
+		t.Errorf("gtm show(%+v), want 1 got %d, %s", args, rc, ui.ErrorWriter)
+	}
+	if !strings.Contains(ui.OutputWriter.String(), "Usage:") {
+		t.Errorf("gtm show(%+v), want 'Usage:'  got %d, %s", args, rc, ui.OutputWriter.String())
+	}
+}
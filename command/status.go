@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/DEVELOPEST/gtm-core/metric"
-	"github.com/DEVELOPEST/gtm-core/note"
 	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/report"
 	"github.com/DEVELOPEST/gtm-core/util"
@@ -38,10 +37,13 @@ Options:
 
   -terminal-off=false        Exclude time spent in terminal (Terminal plug-in is required)
   -app-off=false             Exclude time spent in apps
+  -include=""                Only include time for files matching a glob, repeatable, i.e. -include 'src/**'
+  -exclude=""                Exclude time for files matching a glob, repeatable, i.e. -exclude 'vendor/**'
   -color=false               Always output color even if no terminal is detected, i.e 'gtm status -color | less -R'
   -total-only=false          Only display total pending time
   -long-duration             If total-only, display total pending time in long duration format
-  -tags=""                   Project tags to report status for, i.e --tags tag1,tag2
+  -tags=""                   Project tags to report status for, i.e --tags tag1,tag2 or a query, i.e. --tags 'client-x AND NOT internal'
+                             or --tags '(a OR b) AND go', operators are NOT, AND and OR, a comma is the same as OR
   -all=false                 Show status for all projects
   -auto-log=""               Format output for auto logging time [gitlab, jira]
   -cwd=""                    Set cwd (useful for plugins)
@@ -53,10 +55,13 @@ Options:
 func (c StatusCmd) Run(args []string) int {
 	var color, terminalOff, appOff, totalOnly, all, profile, longDuration bool
 	var tags, cwd, autoLog string
+	var include, exclude globsFlag
 	cmdFlags := flag.NewFlagSet("status", flag.ContinueOnError)
 	cmdFlags.BoolVar(&color, "color", false, "Always output color even if no terminal is detected. Use this with pagers i.e 'less -R' or 'more -R'")
 	cmdFlags.BoolVar(&terminalOff, "terminal-off", false, "Exclude time spent in terminal (Terminal plugin is required)")
 	cmdFlags.BoolVar(&appOff, "app-off", false, "Exclude time spent in apps")
+	cmdFlags.Var(&include, "include", "Only include time for files matching a glob")
+	cmdFlags.Var(&exclude, "exclude", "Exclude time for files matching a glob")
 	cmdFlags.BoolVar(&totalOnly, "total-only", false, "Only display total time")
 	cmdFlags.BoolVar(&longDuration, "long-duration", false, "Display total time in long duration format")
 	cmdFlags.StringVar(&tags, "tags", "", "Project tags to show status on")
@@ -75,10 +80,9 @@ func (c StatusCmd) Run(args []string) int {
 	}
 
 	var (
-		projects   []string
-		err        error
-		commitNote note.CommitNote
-		out        string
+		projects []string
+		err      error
+		out      string
 	)
 
 	index, err := project.NewIndex()
@@ -87,17 +91,25 @@ func (c StatusCmd) Run(args []string) int {
 		return 1
 	}
 
-	var tagList []string
+	var tagQuery project.TagQuery
 	if tags != "" {
-		tagList = util.Map(strings.Split(tags, ","), strings.TrimSpace)
+		if tagQuery, err = project.ParseTagQuery(tags); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
 	}
 
 	if cwd != "" {
-		projects, err = index.Get(tagList, all, cwd)
+		projects, err = index.Get(tagQuery, all, cwd)
 	} else {
-		projects, err = index.Get(tagList, all)
+		projects, err = index.Get(tagQuery, all)
 	}
-	if err != nil {
+	rc := 0
+	if _, ok := err.(project.TagsError); ok {
+		// projects with unreadable tags are reported and left out
+		c.UI.Error(err.Error())
+		rc = 1
+	} else if err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
@@ -108,19 +120,29 @@ func (c StatusCmd) Run(args []string) int {
 		LongDuration: longDuration,
 		TerminalOff:  terminalOff,
 		AppOff:       appOff,
+		Include:      include,
+		Exclude:      exclude,
 		Color:        color}
 
-	for _, projPath := range projects {
-		if commitNote, err = metric.Process(true, projPath); err != nil {
-			c.UI.Error(err.Error())
-			return 1
-		}
-		o, err := report.Status(commitNote, options, projPath)
+	// process projects concurrently, a project with an error is reported and left out
+	outs := make([]string, len(projects))
+	errs := make([]error, len(projects))
+	util.Parallel(len(projects), func(i int) {
+		commitNote, err := metric.Process(true, metric.ResolutionHour, projects[i])
 		if err != nil {
-			c.UI.Error(err.Error())
-			return 1
+			errs[i] = err
+			return
+		}
+		outs[i], errs[i] = report.Status(commitNote, options, projects[i])
+	})
+
+	for i := range projects {
+		if errs[i] != nil {
+			c.UI.Error(projectError(projects[i], errs[i], len(projects)))
+			rc = 1
+			continue
 		}
-		out += o
+		out += outs[i]
 	}
 
 	if totalOnly {
@@ -129,7 +151,7 @@ func (c StatusCmd) Run(args []string) int {
 	} else {
 		c.UI.Output(out)
 	}
-	return 0
+	return rc
 }
 
 // Synopsis returns help for status command
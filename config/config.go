@@ -0,0 +1,121 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/DEVELOPEST/gtm-core/billing"
+	"github.com/DEVELOPEST/gtm-core/workhours"
+)
+
+// Config contains the user settings for gtm
+type Config struct {
+	// Languages maps a language name to file extensions (.go), file names (Dockerfile)
+	// or path globs (db/migrations/*.sql), extending the built-in language table
+	Languages map[string][]string `json:"languages"`
+	// Issues configures how issue keys are found in commits
+	Issues Issues `json:"issues"`
+	// Timesheet configures the timesheet report
+	Timesheet Timesheet `json:"timesheet"`
+	// Billing contains the hourly rates for the invoice report
+	Billing billing.Rates `json:"billing"`
+	// WorkHours is the working hours calendar for the overtime report and work hours filters
+	WorkHours workhours.Config `json:"workHours"`
+	// Notes configures the notes saved with commits
+	Notes Notes `json:"notes"`
+}
+
+// Notes contains the resolution of the note timelines, hour or minute
+type Notes struct {
+	Resolution string `json:"resolution"`
+}
+
+// Issues contains the regular expressions used to extract issue keys,
+// i.e. PROJ-123 or #456, from commit subjects, messages and branches
+type Issues struct {
+	Patterns []string `json:"patterns"`
+}
+
+// Timesheet contains the first day of the week and how timesheet entries are rounded,
+// rounding is none, nearest or up to an increment in minutes
+type Timesheet struct {
+	WeekStart string `json:"weekStart"`
+	Rounding  string `json:"rounding"`
+	Increment int    `json:"increment"`
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(u.HomeDir, ".config", "gtm", "config.json"), nil
+}
+
+// TemplatesDir returns the directory of named user-defined report templates
+func TemplatesDir() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(u.HomeDir, ".config", "gtm", "templates"), nil
+}
+
+// LoadTemplate reads a user-defined report template from a file path,
+// or by name from the templates directory, i.e. standup is templates/standup.tmpl
+func LoadTemplate(name string) (string, error) {
+	p := name
+	if _, err := os.Stat(p); err != nil {
+		dir, err := TemplatesDir()
+		if err != nil {
+			return "", err
+		}
+		p = filepath.Join(dir, name)
+		if filepath.Ext(p) == "" {
+			p += ".tmpl"
+		}
+	}
+
+	raw, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read template %s, %s", name, err)
+	}
+	return string(raw), nil
+}
+
+// Load reads the config file, an empty config is returned if it does not exist
+func Load() (Config, error) {
+	c := Config{}
+
+	p, err := Path()
+	if err != nil {
+		return c, err
+	}
+
+	raw, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, fmt.Errorf("Unable to read config %s, %s", p, err)
+	}
+
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return Config{}, fmt.Errorf("Unable to parse config %s, %s", p, err)
+	}
+
+	// the holidays file is relative to the config directory
+	if h := c.WorkHours.Holidays; h != "" && !filepath.IsAbs(h) {
+		c.WorkHours.Holidays = filepath.Join(filepath.Dir(p), h)
+	}
+	return c, nil
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/DEVELOPEST/gtm-core/epoch"
+	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/util"
 )
 
@@ -22,6 +23,14 @@ func Record(file string) error {
 		return err
 	}
 
+	ignore, err := project.LoadIgnore(filepath.Dir(gtmPath))
+	if err != nil {
+		return err
+	}
+	if ignore.Ignored(sourcePath) {
+		return project.ErrFileIgnored
+	}
+
 	return writeEventFile(sourcePath, gtmPath)
 }
 
@@ -37,6 +46,12 @@ func Process(gtmPath string, interim bool) (map[int64]map[string]int, error) {
 		return events, err
 	}
 
+	// events recorded before a file was ignored are dropped
+	ignore, err := project.LoadIgnore(filepath.Dir(gtmPath))
+	if err != nil {
+		return events, err
+	}
+
 	filesToRemove := []string{}
 	var prevEpoch int64
 	var prevFilePath string
@@ -67,6 +82,10 @@ func Process(gtmPath string, interim bool) (map[int64]map[string]int, error) {
 			continue
 		}
 
+		if ignore.Ignored(sourcePath) {
+			continue
+		}
+
 		if _, ok := events[fileEpoch]; !ok {
 			events[fileEpoch] = make(map[string]int)
 		}
@@ -117,3 +117,41 @@ func TestProcess(t *testing.T) {
 		t.Fatalf("Process(%s, %s, true), want file count 0, got %d", workdir, gtmPath, len(files))
 	}
 }
+
+func TestIgnore(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+
+	curDir, err := os.Getwd()
+	util.CheckFatal(t, err)
+	defer os.Chdir(curDir)
+
+	os.Chdir(repo.Workdir())
+
+	_, err = project.Initialize(false, []string{}, false, "", true, "")
+	util.CheckFatal(t, err)
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("errors.go", filepath.Join("vendor", "errors"), "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496811.event", project.GTMDir, filepath.Join("vendor", "errors", "errors.go"))
+	repo.SaveFile(project.IgnoreFile, "", "vendor/\n")
+
+	sourceFile := filepath.Join(repo.Workdir(), "vendor", "errors", "errors.go")
+	if err = Record(sourceFile); err != project.ErrFileIgnored {
+		t.Errorf("Record(%s), want error %s, got %v", sourceFile, project.ErrFileIgnored, err)
+	}
+
+	gtmPath := filepath.Join(repo.Workdir(), project.GTMDir)
+	expected := map[int64]map[string]int{
+		int64(1458496800): {filepath.Join("event", "event.go"): 1},
+	}
+
+	got, err := Process(gtmPath, false)
+	if err != nil {
+		t.Fatalf("Process(%s, false), want error nil, got %s", gtmPath, err)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("Process(%s, false)\nwant:\n%+v\ngot:\n%+v", gtmPath, expected, got)
+	}
+}
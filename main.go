@@ -50,6 +50,16 @@ func main() {
 				UI: ui,
 			}, nil
 		},
+		"log": func() (cli.Command, error) {
+			return &command.LogCmd{
+				UI: ui,
+			}, nil
+		},
+		"show": func() (cli.Command, error) {
+			return &command.ShowCmd{
+				UI: ui,
+			}, nil
+		},
 		"status": func() (cli.Command, error) {
 			return &command.StatusCmd{
 				UI: ui,
@@ -76,6 +86,21 @@ func main() {
 				UI: ui,
 			}, nil
 		},
+		"blame": func() (cli.Command, error) {
+			return &command.BlameCmd{
+				UI: ui,
+			}, nil
+		},
+		"check-ignore": func() (cli.Command, error) {
+			return &command.CheckIgnoreCmd{
+				UI: ui,
+			}, nil
+		},
+		"cache clear": func() (cli.Command, error) {
+			return &command.CacheClearCmd{
+				UI: ui,
+			}, nil
+		},
 	}
 
 	exitStatus, err := c.Run()
@@ -13,8 +13,9 @@ import (
 )
 
 // Process events for last git commit and save time spent as a git note
-// If interim is true, process events for the current working and staged files
-func Process(interim bool, projPath ...string) (note.CommitNote, error) {
+// If interim is true, process events for the current working and staged files.
+// The resolution is the period of the note timeline entries.
+func Process(interim bool, resolution Resolution, projPath ...string) (note.CommitNote, error) {
 	defer util.Profile()()
 
 	rootPath, gtmPath, err := project.Paths(projPath...)
@@ -52,7 +53,7 @@ func Process(interim bool, projPath ...string) (note.CommitNote, error) {
 			return note.CommitNote{}, err
 		}
 
-		commitNote, err = buildCommitNote(rootPath, branch, commitMap, readonlyMap)
+		commitNote, err = buildCommitNote(rootPath, branch, commitMap, readonlyMap, resolution)
 		if err != nil {
 			return note.CommitNote{}, err
 		}
@@ -63,7 +64,7 @@ func Process(interim bool, projPath ...string) (note.CommitNote, error) {
 			return note.CommitNote{}, err
 		}
 
-		commitNote, err = buildCommitNote(rootPath, branch, commitMap, readonlyMap)
+		commitNote, err = buildCommitNote(rootPath, branch, commitMap, readonlyMap, resolution)
 		if err != nil {
 			return note.CommitNote{}, err
 		}
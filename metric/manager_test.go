@@ -35,12 +35,12 @@ func TestFullCommit(t *testing.T) {
 	treeID := repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go"))
 	commitID := repo.Commit(treeID)
 
-	_, err = Process(false)
+	_, err = Process(false, ResolutionHour)
 	if err != nil {
 		t.Fatalf("Process(false) - test full commit, want error nil, got %s", err)
 	}
 
-	n, err := scm.ReadNote(commitID.String(), "gtm-data", true)
+	n, err := scm.ReadNote(commitID.String(), "gtm-data", scm.StatsCommit)
 	util.CheckFatal(t, err)
 
 	want := []string{`total:180.*`, `event.go:160.*,m`, `event_test.go:20.*,m`}
@@ -78,12 +78,12 @@ func TestPartialCommit(t *testing.T) {
 	treeID = repo.Stage(filepath.Join("event", "event_test.go"))
 	commitID = repo.Commit(treeID)
 
-	_, err = Process(false)
+	_, err = Process(false, ResolutionHour)
 	if err != nil {
 		t.Fatalf("Process(false) - test full commit, want error nil, got %s", err)
 	}
 
-	n, err := scm.ReadNote(commitID.String(), "gtm-data", true)
+	n, err := scm.ReadNote(commitID.String(), "gtm-data", scm.StatsCommit)
 	util.CheckFatal(t, err)
 
 	want := []string{`total:180`, `event_test.go:20.*,m`, `event.go:160.*,r`}
@@ -96,6 +96,52 @@ func TestPartialCommit(t *testing.T) {
 	}
 }
 
+func TestMinuteResolution(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+
+	curDir, err := os.Getwd()
+	util.CheckFatal(t, err)
+	defer os.Chdir(curDir)
+
+	os.Chdir(repo.Workdir())
+
+	repo.SaveFile("event.go", "event", "")
+	repo.SaveFile("1458496803.event", project.GTMDir, filepath.Join("event", "event.go"))
+	repo.SaveFile("1458496943.event", project.GTMDir, filepath.Join("event", "event.go"))
+
+	treeID := repo.Stage(filepath.Join("event", "event.go"))
+	commitID := repo.Commit(treeID)
+
+	_, err = Process(false, ResolutionMinute)
+	if err != nil {
+		t.Fatalf("Process(false, minute) - test minute resolution, want error nil, got %s", err)
+	}
+
+	n, err := scm.ReadNote(commitID.String(), "gtm-data", scm.StatsCommit)
+	util.CheckFatal(t, err)
+
+	// the event at 18:02:23 is kept in its own minute instead of the 18:00 hour
+	want := `(?s)\[ver:2,[^]]*,res:m,branch:.*event.go:\d+,1458496800:60,.*1458496920:60,m`
+	matched, err := regexp.MatchString(want, n.Note)
+	util.CheckFatal(t, err)
+	if !matched {
+		t.Errorf("Process(false, minute) - test minute resolution, \nwant:\n%s,\ngot:\n%s", want, n.Note)
+	}
+}
+
+func TestParseResolution(t *testing.T) {
+	for s, want := range map[string]Resolution{"": ResolutionHour, "hour": ResolutionHour, "minute": ResolutionMinute} {
+		got, err := ParseResolution(s)
+		if err != nil || got != want {
+			t.Errorf("ParseResolution(%q) want %s, got %s %v", s, want, got, err)
+		}
+	}
+	if _, err := ParseResolution("second"); err == nil {
+		t.Errorf("ParseResolution(second) want error, got nil")
+	}
+}
+
 func TestInterim(t *testing.T) {
 	repo := util.NewTestRepo(t, false)
 	defer repo.Remove()
@@ -116,12 +162,12 @@ func TestInterim(t *testing.T) {
 	treeID := repo.Stage(filepath.Join("event", "event.go"), filepath.Join("event", "event_test.go"))
 	commitID := repo.Commit(treeID)
 
-	commitNote, err := Process(true)
+	commitNote, err := Process(true, ResolutionHour)
 	if err != nil {
 		t.Fatalf("Process(false) - test full commit, want error nil, got %s", err)
 	}
 
-	n, err := scm.ReadNote(commitID.String(), "gtm-data", true)
+	n, err := scm.ReadNote(commitID.String(), "gtm-data", scm.StatsCommit)
 	util.CheckFatal(t, err)
 
 	if n.Note != "" {
@@ -86,6 +86,27 @@ func (f *FileMetric) AddTimeSpent(ep int64, t int) {
 	f.Timeline[ep] += t
 }
 
+// Resolution is the period of the timeline entries saved in notes
+type Resolution string
+
+const (
+	// ResolutionHour sums the timeline by hour, the default
+	ResolutionHour Resolution = "hour"
+	// ResolutionMinute keeps the timeline of the one minute epoch windows
+	ResolutionMinute Resolution = "minute"
+)
+
+// ParseResolution returns the resolution for hour or minute, hour if s is empty
+func ParseResolution(s string) (Resolution, error) {
+	switch Resolution(s) {
+	case "", ResolutionHour:
+		return ResolutionHour, nil
+	case ResolutionMinute:
+		return ResolutionMinute, nil
+	}
+	return "", fmt.Errorf("Resolution %s not valid, use hour or minute", s)
+}
+
 // Downsample return timeline by hour
 func (f *FileMetric) Downsample() {
 	byHour := map[int64]int{}
@@ -288,19 +309,23 @@ func buildCommitMaps(metricMap map[string]FileMetric) (map[string]FileMetric, ma
 	return commitMap, readonlyMap, nil
 }
 
-// buildCommitNote creates a CommitNote for files in the commit and readonly maps in git repo at rootPath
+// buildCommitNote creates a CommitNote for files in the commit and readonly maps in git repo at rootPath,
+// timelines are summed by hour unless the resolution is minute
 func buildCommitNote(
 	rootPath string,
 	branch string,
 	commitMap map[string]FileMetric,
-	readonlyMap map[string]FileMetric) (note.CommitNote, error) {
+	readonlyMap map[string]FileMetric,
+	resolution Resolution) (note.CommitNote, error) {
 
 	defer util.Profile()()
 
 	var flsModified []note.FileDetail
 
 	for _, fm := range commitMap {
-		fm.Downsample()
+		if resolution != ResolutionMinute {
+			fm.Downsample()
+		}
 		status := "m"
 		if _, err := os.Stat(filepath.Join(rootPath, fm.SourceFile)); os.IsNotExist(err) {
 			status = "d"
@@ -312,7 +337,9 @@ func buildCommitNote(
 
 	var flsReadonly []note.FileDetail
 	for _, fm := range readonlyMap {
-		fm.Downsample()
+		if resolution != ResolutionMinute {
+			fm.Downsample()
+		}
 		status := "r"
 		if _, err := os.Stat(filepath.Join(rootPath, fm.SourceFile)); os.IsNotExist(err) {
 			status = "d"
@@ -324,7 +351,11 @@ func buildCommitNote(
 	fls := append(flsModified, flsReadonly...)
 	sort.Sort(sort.Reverse(note.FileByTime(fls)))
 
-	return note.CommitNote{Files: fls, Branch: branch}, nil
+	n := note.CommitNote{Files: fls, Branch: branch, TZ: util.Now().Format("-0700")}
+	if resolution == ResolutionMinute {
+		n.Resolution = note.ResolutionMinute
+	}
+	return n, nil
 }
 
 // buildInterimCommitMaps creates the write and read-only commit maps
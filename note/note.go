@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/util"
@@ -20,39 +21,132 @@ import (
 type CommitNote struct {
 	Files  []FileDetail
 	Branch string
+	// TZ is the UTC offset of the recorder when the note was saved, i.e. +0200, empty for older notes
+	TZ string
+	// Resolution is ResolutionMinute for timelines with an entry per minute, empty for an entry per hour
+	Resolution string
 }
 
-// FilterOutTerminal filters out terminal time from commit note
-func (n CommitNote) FilterOutTerminal() CommitNote {
+// ResolutionMinute is the resolution of notes saved with a timeline entry per minute
+const ResolutionMinute = "m"
+
+// Period returns the period of each timeline entry of the note
+func (n CommitNote) Period() time.Duration {
+	if n.Resolution == ResolutionMinute {
+		return time.Minute
+	}
+	return time.Hour
+}
+
+// Location returns a fixed time zone with the note's UTC offset, nil if the offset is unknown
+func (n CommitNote) Location() *time.Location {
+	t, err := time.Parse("-0700", n.TZ)
+	if err != nil {
+		return nil
+	}
+	_, offset := t.Zone()
+	return time.FixedZone(n.TZ, offset)
+}
+
+// Filter returns the commit note with the files keep returns true for,
+// all other fields of the note are preserved
+func (n CommitNote) Filter(keep func(f FileDetail) bool) CommitNote {
 	var fds []FileDetail
 	for _, f := range n.Files {
-		if !f.IsTerminal() {
+		if keep(f) {
 			fds = append(fds, f)
 		}
 	}
-	return CommitNote{Files: fds}
+	n.Files = fds
+	return n
+}
+
+// FilterOutTerminal filters out terminal time from commit note
+func (n CommitNote) FilterOutTerminal() CommitNote {
+	return n.Filter(func(f FileDetail) bool { return !f.IsTerminal() })
 }
 
 // FilterOutApp filters out app time from commit note
 func (n CommitNote) FilterOutApp() CommitNote {
-	var fds []FileDetail
-	for _, f := range n.Files {
-		if !f.IsApp() {
-			fds = append(fds, f)
-		}
-	}
-	return CommitNote{Files: fds}
+	return n.Filter(func(f FileDetail) bool { return !f.IsApp() })
 }
 
 // FilterOutSubdir removes all notes not related to subdir
 func (n CommitNote) FilterOutSubdir(subdir string) CommitNote {
+	return n.Filter(func(f FileDetail) bool { return strings.HasPrefix(f.SourceFile, subdir) })
+}
+
+// FilterFiles removes the files not selected by the file filter and the time not kept by the filter
+func (n CommitNote) FilterFiles(filter FileFilter) CommitNote {
+	if !filter.IsSet() {
+		return n
+	}
+	n = n.Filter(func(f FileDetail) bool { return filter.Match(f.SourceFile) })
+	if filter.Time == nil {
+		return n
+	}
+
 	var fds []FileDetail
 	for _, f := range n.Files {
-		if strings.HasPrefix(f.SourceFile, subdir) {
-			fds = append(fds, f)
+		timeline := map[int64]int{}
+		total := 0
+		for epoch, secs := range f.Timeline {
+			if kept := filter.Time(epoch, secs); kept > 0 {
+				timeline[epoch] = kept
+				total += kept
+			}
+		}
+		if total == 0 {
+			continue
 		}
+		f.Timeline = timeline
+		f.TimeSpent = total
+		fds = append(fds, f)
+	}
+	n.Files = fds
+	return n
+}
+
+// FileFilter selects files by subdirectory and by include and exclude globs.
+// Globs are slash separated, ** matches any number of directories and
+// a glob without a slash matches the file name in any directory, i.e. *.pb.go
+type FileFilter struct {
+	Subdir  string
+	Include []string
+	Exclude []string
+	// Time returns the seconds to keep of the seconds spent in the timeline period starting at epoch,
+	// i.e. the time within working hours, all time is kept if nil
+	Time func(epoch int64, secs int) int
+}
+
+// IsSet returns true if the filter removes any files or time
+func (f FileFilter) IsSet() bool {
+	return f.Subdir != "" || len(f.Include) > 0 || len(f.Exclude) > 0 || f.Time != nil
+}
+
+// Match returns true if the file is within the subdirectory, matches an include glob if there are any
+// and does not match an exclude glob
+func (f FileFilter) Match(sourceFile string) bool {
+	if f.Subdir != "" && !strings.HasPrefix(sourceFile, f.Subdir) {
+		return false
 	}
-	return CommitNote{Files: fds}
+	name := filepath.ToSlash(sourceFile)
+	if len(f.Include) > 0 && !matchAny(f.Include, name) {
+		return false
+	}
+	return !matchAny(f.Exclude, name)
+}
+
+func matchAny(globs []string, name string) bool {
+	for _, g := range globs {
+		if !strings.Contains(g, "/") {
+			g = "**/" + g
+		}
+		if util.GlobMatch(g, name) {
+			return true
+		}
+	}
+	return false
 }
 
 // Total returns the total time for a commit note
@@ -64,13 +158,32 @@ func (n CommitNote) Total() int {
 	return total
 }
 
-// Marshal converts a commit note to a serialized string
+// Marshal converts a commit note to a serialized string.
+// Notes with a time zone or resolution are version 2, their header does not match the
+// version 1 header so older readers skip the note instead of reading the fields as the branch.
 func Marshal(n CommitNote) string {
+	if n.TZ == "" && n.Resolution == "" {
+		s := fmt.Sprintf("[ver:%s,total:%d,branch:%s]\n", "1", n.Total(), n.Branch)
+		return s + marshalFiles(n.Files)
+	}
+
+	s := fmt.Sprintf("[ver:%s,total:%d", "2", n.Total())
+	if n.TZ != "" {
+		s += fmt.Sprintf(",tz:%s", n.TZ)
+	}
+	if n.Resolution != "" {
+		s += fmt.Sprintf(",res:%s", n.Resolution)
+	}
+	s += fmt.Sprintf(",branch:%s]\n", n.Branch)
+	return s + marshalFiles(n.Files)
+}
+
+func marshalFiles(files []FileDetail) string {
 	var (
+		s        string
 		filePath string
 	)
-	s := fmt.Sprintf("[ver:%s,total:%d,branch:%s]\n", "1", n.Total(), n.Branch)
-	for _, fl := range n.Files {
+	for _, fl := range files {
 		// nomralize file paths to unix convention
 		filePath = strings.Replace(fl.SourceFile, ":", "->", -1)
 		s += fmt.Sprintf("%s:%d,", filepath.ToSlash(filePath), fl.TimeSpent)
@@ -88,21 +201,33 @@ func UnMarshal(s string) (CommitNote, error) {
 		version string
 		files   []FileDetail
 		branch  string
+		tz      string
+		// resolution is minute if all concatenated notes have minute timelines
+		resolution string
+		hourly     bool
 	)
 
-	reHeader := regexp.MustCompile(`\[ver:(\d+),total:(\d+)(\s*|,branch:([^]]+))]`)
+	reHeader := regexp.MustCompile(`\[ver:(\d+),total:(\d+)(,tz:([+-]\d{4}))?(,res:(m))?(\s*|,branch:([^]]*))]`)
 
 	lines := strings.Split(s, "\n")
 	for lineIdx := 0; lineIdx < len(lines); lineIdx++ {
 		switch {
 		case strings.TrimSpace(lines[lineIdx]) == "":
+			// blank lines separate concatenated notes and end a note, each header sets the branch
 			version = ""
-			branch = ""
 		case reHeader.MatchString(lines[lineIdx]):
 			matches := reHeader.FindStringSubmatch(lines[lineIdx])
 			version = matches[1]
-			branch = matches[4]
-		case version == "1":
+			branch = matches[8]
+			if matches[4] != "" {
+				tz = matches[4]
+			}
+			if matches[6] == ResolutionMinute && !hourly {
+				resolution = ResolutionMinute
+			} else {
+				resolution, hourly = "", true
+			}
+		case version == "1" || version == "2":
 			fieldGroups := strings.Split(lines[lineIdx], ",")
 			if len(fieldGroups) < 3 {
 				return CommitNote{}, fmt.Errorf("Unable to unmarshal time logged, format invalid, %s", lines[lineIdx])
@@ -178,7 +303,7 @@ func UnMarshal(s string) (CommitNote, error) {
 		}
 	}
 	sort.Sort(sort.Reverse(FileByTime(files)))
-	return CommitNote{Files: files, Branch: branch}, nil
+	return CommitNote{Files: files, Branch: branch, TZ: tz, Resolution: resolution}, nil
 }
 
 // FileDetail contains a source file's time metrics
@@ -6,7 +6,10 @@ package note
 
 import (
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestUnMarshallTimeLog(t *testing.T) {
@@ -80,3 +83,176 @@ environment/drone/test.go:60,1460070000:60,r
 	}
 
 }
+
+func TestFilterFiles(t *testing.T) {
+	n := CommitNote{
+		Branch: "feature/filters",
+		Files: []FileDetail{
+			{SourceFile: "main.go", TimeSpent: 60},
+			{SourceFile: "vendor/github.com/pkg/errors/errors.go", TimeSpent: 120},
+			{SourceFile: "api/service.pb.go", TimeSpent: 180},
+			{SourceFile: "api/service.go", TimeSpent: 240},
+		},
+	}
+
+	cases := []struct {
+		filter FileFilter
+		want   []string
+	}{
+		{FileFilter{}, []string{"main.go", "vendor/github.com/pkg/errors/errors.go", "api/service.pb.go", "api/service.go"}},
+		{FileFilter{Exclude: []string{"vendor/**", "*.pb.go"}}, []string{"main.go", "api/service.go"}},
+		{FileFilter{Include: []string{"api/**"}}, []string{"api/service.pb.go", "api/service.go"}},
+		{FileFilter{Include: []string{"api/**"}, Exclude: []string{"*.pb.go"}}, []string{"api/service.go"}},
+		{FileFilter{Subdir: "api", Include: []string{"*.go"}}, []string{"api/service.pb.go", "api/service.go"}},
+	}
+
+	for _, tc := range cases {
+		got := n.FilterFiles(tc.filter)
+		files := []string{}
+		for _, f := range got.Files {
+			files = append(files, f.SourceFile)
+		}
+		if !reflect.DeepEqual(files, tc.want) {
+			t.Errorf("FilterFiles(%+v) want %v, got %v", tc.filter, tc.want, files)
+		}
+		if got.Branch != n.Branch {
+			t.Errorf("FilterFiles(%+v) want branch %s, got %s", tc.filter, n.Branch, got.Branch)
+		}
+	}
+
+	for name, got := range map[string]CommitNote{
+		"FilterOutTerminal": n.FilterOutTerminal(),
+		"FilterOutApp":      n.FilterOutApp(),
+		"FilterOutSubdir":   n.FilterOutSubdir("api"),
+	} {
+		if got.Branch != n.Branch {
+			t.Errorf("%s() want branch %s, got %s", name, n.Branch, got.Branch)
+		}
+	}
+}
+
+func TestUnMarshalBranch(t *testing.T) {
+	tests := []struct {
+		note string
+		want string
+	}{
+		// marshaled notes end with a newline
+		{"[ver:1,total:60,branch:master]\nmain.go:60,1460066400:60,m\n", "master"},
+		{"[ver:1,total:60,branch:master]\nmain.go:60,1460066400:60,m\n\n[ver:1,total:60,branch:feature]\nmain.go:60,1460070000:60,m\n", "feature"},
+		{"[ver:1,total:60,branch:master]\nmain.go:60,1460066400:60,m\n\n[ver:1,total:60]\nmain.go:60,1460070000:60,m\n", ""},
+	}
+
+	for _, tc := range tests {
+		got, err := UnMarshal(tc.note)
+		if err != nil {
+			t.Fatalf("UnMarshal(%s) want error nil, got %s", tc.note, err)
+		}
+		if got.Branch != tc.want {
+			t.Errorf("UnMarshal(%s) want branch %s, got %s", tc.note, tc.want, got.Branch)
+		}
+	}
+}
+
+func TestMarshalTimeZone(t *testing.T) {
+	n := CommitNote{
+		Branch: "master",
+		TZ:     "-0530",
+		Files: []FileDetail{
+			{SourceFile: "main.go", TimeSpent: 60, Timeline: map[int64]int{int64(1460066400): 60}, Status: "m"},
+		},
+	}
+
+	s := Marshal(n)
+	if !strings.HasPrefix(s, "[ver:2,total:60,tz:-0530,branch:master]\n") {
+		t.Errorf("Marshal(%+v) want header with tz, got %s", n, s)
+	}
+	got, err := UnMarshal(s)
+	if err != nil {
+		t.Fatalf("UnMarshal(%s) want error nil, got %s", s, err)
+	}
+	if got.TZ != n.TZ || !reflect.DeepEqual(n.Files, got.Files) {
+		t.Errorf("UnMarshal(%s) want %+v, got %+v", s, n, got)
+	}
+
+	loc := got.Location()
+	if loc == nil {
+		t.Fatalf("Location() want zone -0530, got nil")
+	}
+	if _, offset := time.Unix(1460066400, 0).In(loc).Zone(); offset != -(5*3600 + 30*60) {
+		t.Errorf("Location() want offset %d, got %d", -(5*3600 + 30*60), offset)
+	}
+
+	n.TZ = ""
+	if loc := n.Location(); loc != nil {
+		t.Errorf("Location() without tz want nil, got %s", loc)
+	}
+}
+
+func TestMarshalResolution(t *testing.T) {
+	// minute entries at the start of an hour are still minutes
+	n := CommitNote{
+		Branch:     "master",
+		Resolution: ResolutionMinute,
+		Files: []FileDetail{
+			{SourceFile: "main.go", TimeSpent: 60, Timeline: map[int64]int{int64(1460066400): 60}, Status: "m"},
+		},
+	}
+
+	s := Marshal(n)
+	if !strings.HasPrefix(s, "[ver:2,total:60,res:m,branch:master]\n") {
+		t.Errorf("Marshal(%+v) want header with res, got %s", n, s)
+	}
+	got, err := UnMarshal(s)
+	if err != nil {
+		t.Fatalf("UnMarshal(%s) want error nil, got %s", s, err)
+	}
+	if got.Resolution != ResolutionMinute || got.Period() != time.Minute {
+		t.Errorf("UnMarshal(%s) want minute resolution, got %q", s, got.Resolution)
+	}
+
+	// a note with hour entries makes concatenated notes hourly
+	hourly := Marshal(CommitNote{Branch: "master", Files: n.Files})
+	for _, s := range []string{hourly, s + "\n" + hourly, hourly + "\n" + s} {
+		got, err := UnMarshal(s)
+		if err != nil {
+			t.Fatalf("UnMarshal(%s) want error nil, got %s", s, err)
+		}
+		if got.Resolution != "" || got.Period() != time.Hour {
+			t.Errorf("UnMarshal(%s) want hour resolution, got %q", s, got.Resolution)
+		}
+	}
+}
+
+func TestMarshalVersion1Readers(t *testing.T) {
+	// the header regex of readers that only know version 1 notes
+	reHeader := regexp.MustCompile(`\[ver:(\d+),total:(\d+)(\s*|,branch:([^]]+))]`)
+
+	files := []FileDetail{
+		{SourceFile: "main.go", TimeSpent: 60, Timeline: map[int64]int{int64(1460066400): 60}, Status: "m"},
+	}
+
+	// notes without a time zone or resolution are still read by older readers
+	s := Marshal(CommitNote{Branch: "master", Files: files})
+	if m := reHeader.FindStringSubmatch(s); m == nil || m[1] != "1" || m[4] != "master" {
+		t.Errorf("version 1 header of %s want version 1 and branch master, got %q", s, m)
+	}
+
+	// older readers skip notes with a time zone or resolution instead of misreading the branch
+	for _, n := range []CommitNote{
+		{Branch: "master", TZ: "+0200", Files: files},
+		{Branch: "master", Resolution: ResolutionMinute, Files: files},
+		{Branch: "master", TZ: "+0200", Resolution: ResolutionMinute, Files: files},
+	} {
+		s := Marshal(n)
+		if m := reHeader.FindStringSubmatch(s); m != nil {
+			t.Errorf("version 1 header of %s want no match, got %q", s, m)
+		}
+		got, err := UnMarshal(s)
+		if err != nil {
+			t.Fatalf("UnMarshal(%s) want error nil, got %s", s, err)
+		}
+		if got.Branch != n.Branch || got.TZ != n.TZ || got.Resolution != n.Resolution || !reflect.DeepEqual(files, got.Files) {
+			t.Errorf("UnMarshal(%s) want %+v, got %+v", s, n, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+// IgnoreFile is the name of the file with the gitignore syntax rules of files not tracked.
+// It is read from the repository root and the .gtm directory.
+const IgnoreFile = ".gtmignore"
+
+// IgnoreRule is a rule from an ignore file
+type IgnoreRule struct {
+	// Source is the ignore file and Line the line number of the rule
+	Source  string
+	Line    int
+	Pattern string
+	// Negate is true for ! rules that re-include files
+	Negate bool
+	// DirOnly is true for rules ending in / that only match directories
+	DirOnly bool
+
+	glob string
+}
+
+// String returns the rule as source:line:pattern
+func (r IgnoreRule) String() string {
+	return fmt.Sprintf("%s:%d:%s", r.Source, r.Line, r.Pattern)
+}
+
+// IgnoreRules contains the rules of files not tracked, the last matching rule decides
+type IgnoreRules []IgnoreRule
+
+// LoadIgnore reads the ignore rules from .gtmignore in the repository root and in .gtm,
+// rules in .gtm are applied after the rules in the root
+func LoadIgnore(rootPath string) (IgnoreRules, error) {
+	rules := IgnoreRules{}
+	for _, p := range []string{
+		filepath.Join(rootPath, IgnoreFile),
+		filepath.Join(rootPath, GTMDir, IgnoreFile)} {
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return IgnoreRules{}, err
+		}
+		source, err := filepath.Rel(rootPath, p)
+		if err != nil {
+			source = p
+		}
+		rules = append(rules, ParseIgnore(string(b), filepath.ToSlash(source))...)
+	}
+	return rules, nil
+}
+
+// ParseIgnore returns the rules in text using gitignore syntax
+func ParseIgnore(text, source string) IgnoreRules {
+	rules := IgnoreRules{}
+	for i, line := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n") {
+		pattern := strings.TrimRight(line, " \t")
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		rule := IgnoreRule{Source: source, Line: i + 1, Pattern: pattern}
+		switch {
+		case strings.HasPrefix(pattern, "!"):
+			rule.Negate = true
+			pattern = pattern[1:]
+		case strings.HasPrefix(pattern, `\!`), strings.HasPrefix(pattern, `\#`):
+			pattern = pattern[1:]
+		}
+		if strings.HasSuffix(pattern, "/") {
+			rule.DirOnly = true
+			pattern = strings.TrimRight(pattern, "/")
+		}
+		if pattern == "" {
+			continue
+		}
+
+		// patterns with a slash are relative to the repository root, others match at any level
+		if strings.Contains(pattern, "/") {
+			rule.glob = strings.TrimPrefix(pattern, "/")
+		} else {
+			rule.glob = "**/" + pattern
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Ignored returns true if the source path relative to the repository root is ignored
+func (r IgnoreRules) Ignored(sourcePath string) bool {
+	rule, ok := r.Match(sourcePath)
+	return ok && !rule.Negate
+}
+
+// Match returns the rule that decides if the source path is ignored and false if no rule matches.
+// As with gitignore, files in an ignored directory can not be re-included.
+func (r IgnoreRules) Match(sourcePath string) (IgnoreRule, bool) {
+	return r.match(sourcePath, false)
+}
+
+// MatchDir returns the rule that decides if the directory is ignored and false if no rule matches
+func (r IgnoreRules) MatchDir(sourcePath string) (IgnoreRule, bool) {
+	return r.match(sourcePath, true)
+}
+
+func (r IgnoreRules) match(sourcePath string, isDir bool) (IgnoreRule, bool) {
+	if len(r) == 0 {
+		return IgnoreRule{}, false
+	}
+
+	parts := strings.Split(filepath.ToSlash(sourcePath), "/")
+	for i := 1; i < len(parts); i++ {
+		if rule, ok := r.last(strings.Join(parts[:i], "/"), true); ok && !rule.Negate {
+			return rule, true
+		}
+	}
+	return r.last(strings.Join(parts, "/"), isDir)
+}
+
+// last returns the last rule matching the slash separated path
+func (r IgnoreRules) last(p string, isDir bool) (IgnoreRule, bool) {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].DirOnly && !isDir {
+			continue
+		}
+		if util.GlobMatch(r[i].glob, p) {
+			return r[i], true
+		}
+	}
+	return IgnoreRule{}, false
+}
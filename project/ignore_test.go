@@ -0,0 +1,106 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	rules := ParseIgnore(`
+# lockfiles and generated code
+package-lock.json
+*.pb.go
+!keep.pb.go
+/build
+vendor/
+docs/**/*.html
+\#notes.txt
+`, IgnoreFile)
+
+	cases := []struct {
+		path string
+		want bool
+		line int
+	}{
+		{"package-lock.json", true, 3},
+		{"web/package-lock.json", true, 3},
+		{"api/service.pb.go", true, 4},
+		{"api/keep.pb.go", false, 5},
+		{"build/main", true, 6},
+		{"src/build/main", false, 0},
+		{"vendor/github.com/pkg/errors/errors.go", true, 7},
+		{"vendor", false, 0},
+		{"src/vendor/a.go", true, 7},
+		{"docs/api/v1/index.html", true, 8},
+		{"docs/index.md", false, 0},
+		{"#notes.txt", true, 9},
+		{"main.go", false, 0},
+	}
+
+	for _, tc := range cases {
+		if got := rules.Ignored(tc.path); got != tc.want {
+			t.Errorf("Ignored(%s) want %t, got %t", tc.path, tc.want, got)
+		}
+		rule, ok := rules.Match(tc.path)
+		if tc.line == 0 && ok && !rule.Negate {
+			t.Errorf("Match(%s) want no rule, got %s", tc.path, rule)
+		}
+		if tc.line != 0 && rule.Line != tc.line {
+			t.Errorf("Match(%s) want rule on line %d, got %s", tc.path, tc.line, rule)
+		}
+	}
+
+	for _, p := range []string{"vendor", "src/vendor"} {
+		if rule, ok := rules.MatchDir(p); !ok || rule.Line != 7 {
+			t.Errorf("MatchDir(%s) want rule on line 7, got %t %s", p, ok, rule)
+		}
+	}
+
+	// files in an ignored directory can not be re-included
+	rules = ParseIgnore("vendor/\n!vendor/keep.go\n", IgnoreFile)
+	if !rules.Ignored("vendor/keep.go") {
+		t.Errorf("Ignored(vendor/keep.go) want true, got false")
+	}
+}
+
+func TestLoadIgnore(t *testing.T) {
+	rootPath, err := ioutil.TempDir("", "gtm")
+	if err != nil {
+		t.Fatalf("Unable to create tempory directory, %s", err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	if err := os.Mkdir(filepath.Join(rootPath, GTMDir), 0700); err != nil {
+		t.Fatalf("Unable to create .gtm directory, %s", err)
+	}
+
+	rules, err := LoadIgnore(rootPath)
+	if err != nil || len(rules) != 0 {
+		t.Errorf("LoadIgnore() without ignore files want no rules, got %+v, %v", rules, err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(rootPath, IgnoreFile), []byte("*.lock\n"), 0644); err != nil {
+		t.Fatalf("Unable to write %s, %s", IgnoreFile, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(rootPath, GTMDir, IgnoreFile), []byte("!yarn.lock\n"), 0644); err != nil {
+		t.Fatalf("Unable to write %s, %s", IgnoreFile, err)
+	}
+
+	rules, err = LoadIgnore(rootPath)
+	if err != nil {
+		t.Fatalf("LoadIgnore() want error nil, got %s", err)
+	}
+	if !rules.Ignored("Cargo.lock") {
+		t.Errorf("Ignored(Cargo.lock) want true, got false")
+	}
+	rule, _ := rules.Match("yarn.lock")
+	if rules.Ignored("yarn.lock") || rule.String() != ".gtm/.gtmignore:1:!yarn.lock" {
+		t.Errorf("Ignored(yarn.lock) want not ignored by .gtm/.gtmignore:1:!yarn.lock, got %s", rule)
+	}
+}
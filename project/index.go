@@ -6,11 +6,13 @@ package project
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -35,27 +37,46 @@ func NewIndex() (Index, error) {
 	return i, nil
 }
 
-// Get finds projects by tags or all projects or the project in the current directory
-func (i *Index) Get(tags []string, all bool, cwd ...string) ([]string, error) {
+// TagsError lists the projects left out of a tag query because their tags could not be read
+type TagsError []error
+
+func (e TagsError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Get finds projects by tag query or all projects or the project in the current directory.
+// Projects whose tags can not be read are left out and returned with the matched projects as a TagsError.
+func (i *Index) Get(tags TagQuery, all bool, cwd ...string) ([]string, error) {
 	switch {
 	case all:
 		err := i.clean()
 		return i.projects(), err
-	case len(tags) > 0:
+	case tags != nil:
 		if err := i.clean(); err != nil {
 			return []string{}, err
 		}
-		var projectsWithTags []string
+		var (
+			projectsWithTags []string
+			errs             TagsError
+		)
 		for _, p := range i.projects() {
 			found, err := i.hasTags(p, tags)
 			if err != nil {
-				return []string{}, nil
+				errs = append(errs, err)
+				continue
 			}
 			if found {
 				projectsWithTags = append(projectsWithTags, p)
 			}
 		}
 		sort.Strings(projectsWithTags)
+		if len(errs) > 0 {
+			return projectsWithTags, errs
+		}
 		return projectsWithTags, nil
 	default:
 		curProjPath, _, err := Paths(cwd...)
@@ -131,19 +152,12 @@ func (i *Index) save() error {
 	return ioutil.WriteFile(p, bytes, 0644)
 }
 
-func (i *Index) hasTags(projectPath string, tagsToFind []string) (bool, error) {
-	tags, err := LoadTags(filepath.Join(projectPath, ".gtm"))
+func (i *Index) hasTags(projectPath string, tags TagQuery) (bool, error) {
+	projectTags, err := LoadTags(filepath.Join(projectPath, GTMDir))
 	if err != nil {
-		return false, err
-	}
-	for _, t1 := range tags {
-		for _, t2 := range tagsToFind {
-			if t1 == t2 {
-				return true, nil
-			}
-		}
+		return false, fmt.Errorf("Unable to read tags of project %s, %s", projectPath, err)
 	}
-	return false, nil
+	return tags.Match(projectTags), nil
 }
 
 func (i *Index) removeNotFound(projectPath string) {
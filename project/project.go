@@ -26,6 +26,8 @@ var (
 	ErrNotInitialized = errors.New("Git Time Metric is not initialized")
 	// ErrFileNotFound is raised when record an event for a file that does not exist
 	ErrFileNotFound = errors.New("File does not exist")
+	// ErrFileIgnored is raised when record an event for a file matching a .gtmignore rule
+	ErrFileIgnored = errors.New("File is ignored")
 	// AppEventFileContentRegex regex for app event files
 	AppEventFileContentRegex = regexp.MustCompile(`\.gtm[\\/](?P<appName>.*)\.(?P<eventType>app|run|build)`)
 )
@@ -0,0 +1,176 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TagQuery is a boolean expression of project tags,
+// i.e. client-x AND NOT internal or (a OR b) AND go
+type TagQuery interface {
+	// Match returns true if a project with the tags is selected
+	Match(tags []string) bool
+	String() string
+}
+
+// ParseTagQuery parses a tag query, the operators are NOT, AND and OR in order of precedence.
+// Operators are case insensitive, parentheses group expressions and a comma is the same as OR,
+// i.e. tag1,tag2 selects projects with either tag.
+func ParseTagQuery(s string) (TagQuery, error) {
+	p := &tagQueryParser{tokens: tokenizeTagQuery(s)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("Invalid tag query %q, no tags", s)
+	}
+	q, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid tag query %q, %s", s, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("Invalid tag query %q, unexpected %s", s, p.tokens[p.pos])
+	}
+	return q, nil
+}
+
+type tagQueryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *tagQueryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *tagQueryParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func isOperator(token, op string) bool {
+	return strings.EqualFold(token, op)
+}
+
+func (p *tagQueryParser) or() (TagQuery, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "OR") || p.peek() == "," {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = tagOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagQueryParser) and() (TagQuery, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for isOperator(p.peek(), "AND") {
+		p.next()
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = tagAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *tagQueryParser) not() (TagQuery, error) {
+	if isOperator(p.peek(), "NOT") {
+		p.next()
+		q, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return tagNot{q}, nil
+	}
+	return p.primary()
+}
+
+func (p *tagQueryParser) primary() (TagQuery, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("unexpected end")
+	case t == "(":
+		q, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return q, nil
+	case t == ")" || t == "," || isOperator(t, "AND") || isOperator(t, "OR"):
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return tagName(t), nil
+}
+
+// tokenizeTagQuery splits a tag query into parentheses, commas and words
+func tokenizeTagQuery(s string) []string {
+	tokens := []string{}
+	word := ""
+	flush := func() {
+		if word != "" {
+			tokens = append(tokens, word)
+			word = ""
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == ',':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			word += string(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type tagName string
+
+func (t tagName) Match(tags []string) bool {
+	for _, tag := range tags {
+		if tag == string(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t tagName) String() string { return string(t) }
+
+type tagNot struct{ q TagQuery }
+
+func (t tagNot) Match(tags []string) bool { return !t.q.Match(tags) }
+func (t tagNot) String() string           { return fmt.Sprintf("NOT %s", t.q) }
+
+type tagAnd struct{ left, right TagQuery }
+
+func (t tagAnd) Match(tags []string) bool { return t.left.Match(tags) && t.right.Match(tags) }
+func (t tagAnd) String() string           { return fmt.Sprintf("(%s AND %s)", t.left, t.right) }
+
+type tagOr struct{ left, right TagQuery }
+
+func (t tagOr) Match(tags []string) bool { return t.left.Match(tags) || t.right.Match(tags) }
+func (t tagOr) String() string           { return fmt.Sprintf("(%s OR %s)", t.left, t.right) }
@@ -0,0 +1,70 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTagQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		tags  []string
+		want  bool
+	}{
+		{"a", []string{"a"}, true},
+		{"a", []string{"b"}, false},
+		{"a,b", []string{"b"}, true},
+		{"a, b", []string{"c"}, false},
+		{"client-x AND NOT internal", []string{"client-x"}, true},
+		{"client-x AND NOT internal", []string{"client-x", "internal"}, false},
+		{"client-x and not internal", []string{"client-x"}, true},
+		{"(a OR b) AND go", []string{"b", "go"}, true},
+		{"(a OR b) AND go", []string{"go"}, false},
+		{"a OR b AND go", []string{"a"}, true},
+		{"NOT NOT a", []string{"a"}, true},
+		{"NOT (a OR b)", []string{}, true},
+	}
+
+	for _, tc := range cases {
+		q, err := ParseTagQuery(tc.query)
+		if err != nil {
+			t.Errorf("ParseTagQuery(%s) want error nil, got %s", tc.query, err)
+			continue
+		}
+		if got := q.Match(tc.tags); got != tc.want {
+			t.Errorf("ParseTagQuery(%s).Match(%v) want %t, got %t, parsed as %s", tc.query, tc.tags, tc.want, got, q)
+		}
+	}
+
+	for _, query := range []string{"", "a AND", "(a OR b", "a b", "AND a", "a,", "a)"} {
+		if _, err := ParseTagQuery(query); err == nil {
+			t.Errorf("ParseTagQuery(%s) want error, got nil", query)
+		}
+	}
+}
+
+func TestHasTagsUnreadable(t *testing.T) {
+	projectPath, err := ioutil.TempDir("", "gtm")
+	if err != nil {
+		t.Fatalf("Unable to create tempory directory, %s", err)
+	}
+	defer os.RemoveAll(projectPath)
+
+	q, err := ParseTagQuery("a")
+	if err != nil {
+		t.Fatalf("ParseTagQuery(a) want error nil, got %s", err)
+	}
+
+	i := Index{}
+	_, err = i.hasTags(projectPath, q)
+	if err == nil || !strings.Contains(err.Error(), projectPath) {
+		t.Errorf("hasTags(%s) want error for project, got %v", filepath.Join(projectPath, GTMDir), err)
+	}
+}
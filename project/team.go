@@ -0,0 +1,79 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Teams maps team names to the email addresses of their members
+type Teams map[string][]string
+
+// TeamsPath returns the location of the default team mapping file
+func TeamsPath() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(u.HomeDir, ".config", "gtm", "teams.json"), nil
+}
+
+// LoadTeams reads the team mapping file at path or the default location if path is empty,
+// a missing default file results in no teams
+func LoadTeams(path string) (Teams, error) {
+	teams := Teams{}
+
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = TeamsPath(); err != nil {
+			return teams, err
+		}
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return teams, nil
+		}
+		return teams, fmt.Errorf("Unable to read team mapping %s, %s", path, err)
+	}
+
+	if err := json.Unmarshal(raw, &teams); err != nil {
+		return Teams{}, fmt.Errorf("Unable to parse team mapping %s, %s", path, err)
+	}
+	return teams, nil
+}
+
+// Members returns the email addresses of a team's members
+func (t Teams) Members(team string) ([]string, error) {
+	members, ok := t[team]
+	if !ok {
+		return []string{}, fmt.Errorf("Team %s not found in team mapping", team)
+	}
+	return members, nil
+}
+
+// Of returns the sorted names of the teams an email address belongs to
+func (t Teams) Of(email string) []string {
+	var teams []string
+	for team, members := range t {
+		for _, m := range members {
+			if strings.EqualFold(strings.TrimSpace(m), email) {
+				teams = append(teams, team)
+				break
+			}
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
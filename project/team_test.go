@@ -0,0 +1,55 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadTeams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "teams.json")
+	content := `{"backend": ["ann@example.com", "bob@example.com"], "frontend": ["Bob@Example.com"]}`
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	teams, err := LoadTeams(p)
+	if err != nil {
+		t.Fatalf("LoadTeams(%s), want error nil got %s", p, err)
+	}
+
+	members, err := teams.Members("backend")
+	if err != nil {
+		t.Errorf("Members(backend), want error nil got %s", err)
+	}
+	if !reflect.DeepEqual(members, []string{"ann@example.com", "bob@example.com"}) {
+		t.Errorf("Members(backend), want [ann@example.com bob@example.com] got %+v", members)
+	}
+
+	if _, err := teams.Members("qa"); err == nil {
+		t.Errorf("Members(qa), want error got nil")
+	}
+
+	if got := teams.Of("bob@example.com"); !reflect.DeepEqual(got, []string{"backend", "frontend"}) {
+		t.Errorf("Of(bob@example.com), want [backend frontend] got %+v", got)
+	}
+	if got := teams.Of("eve@example.com"); len(got) != 0 {
+		t.Errorf("Of(eve@example.com), want [] got %+v", got)
+	}
+
+	if _, err := LoadTeams(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("LoadTeams(missing.json), want error got nil")
+	}
+}
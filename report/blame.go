@@ -0,0 +1,94 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+
+	"github.com/DEVELOPEST/gtm-core/scm"
+)
+
+// blameLine is a line of a file with the time its commit recorded for the file
+type blameLine struct {
+	Hash    string
+	Author  string
+	Seconds int
+	Line    int
+	Text    string
+}
+
+// blameCommit summarizes the lines of a file last changed by a commit
+type blameCommit struct {
+	Hash    string
+	Author  string
+	Date    string
+	Subject string
+	Seconds int
+	Lines   int
+}
+
+type blameCommits []blameCommit
+
+func (b blameCommits) Len() int      { return len(b) }
+func (b blameCommits) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b blameCommits) Less(i, j int) bool {
+	if b[i].Seconds != b[j].Seconds {
+		return b[i].Seconds < b[j].Seconds
+	}
+	if b[i].Lines != b[j].Lines {
+		return b[i].Lines < b[j].Lines
+	}
+	return b[i].Hash > b[j].Hash
+}
+
+// blame returns the blamed lines with the time recorded for the file by their commits
+// and the commits sorted by time, the notes are the notes of the blamed commits
+func (c commitNoteDetails) blame(lines []scm.BlameLine) ([]blameLine, blameCommits) {
+	notes := map[string]commitNoteDetail{}
+	for _, n := range c {
+		notes[n.id] = n
+	}
+
+	blamed := make([]blameLine, 0, len(lines))
+	// commitIdx is the index of a commit id in commits
+	commitIdx := map[string]int{}
+	// commitPaths are the paths whose time is counted for a commit id, a renamed file has lines of several paths
+	commitPaths := map[string]map[string]bool{}
+	commits := blameCommits{}
+	for _, l := range lines {
+		n, ok := notes[l.CommitID]
+		if !ok {
+			// commits without a readable note have no time recorded
+			n = commitNoteDetail{Hash: l.CommitID, Author: l.Author}
+			if len(n.Hash) > 7 {
+				n.Hash = n.Hash[:7]
+			}
+		}
+
+		secs := 0
+		for _, f := range n.Note.Files {
+			if f.SourceFile == l.Path {
+				secs += f.TimeSpent
+			}
+		}
+
+		blamed = append(blamed, blameLine{Hash: n.Hash, Author: l.Author, Seconds: secs, Line: l.Line, Text: l.Text})
+
+		i, ok := commitIdx[l.CommitID]
+		if !ok {
+			i = len(commits)
+			commitIdx[l.CommitID] = i
+			commitPaths[l.CommitID] = map[string]bool{}
+			commits = append(commits, blameCommit{Hash: n.Hash, Author: l.Author, Date: n.Date, Subject: n.Subject})
+		}
+		if !commitPaths[l.CommitID][l.Path] {
+			commitPaths[l.CommitID][l.Path] = true
+			commits[i].Seconds += secs
+		}
+		commits[i].Lines++
+	}
+	sort.Sort(sort.Reverse(commits))
+	return blamed, commits
+}
@@ -0,0 +1,209 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+const (
+	// compareTopFiles is the number of files shown when comparing periods
+	compareTopFiles = 10
+	// trendWidth is the width of the longest bar in the trend
+	trendWidth = 40
+)
+
+// byPeriod returns the notes committed within each period
+func (c commitNoteDetails) byPeriod(periods []util.DateRange) []commitNoteDetails {
+	buckets := make([]commitNoteDetails, len(periods))
+	for _, n := range c {
+		for i, p := range periods {
+			if p.Within(n.When) {
+				buckets[i] = append(buckets[i], n)
+				break
+			}
+		}
+	}
+	return buckets
+}
+
+// comparison contains the current and previous period's time spent
+// and the totals of all periods for the trend, the current period is first
+type comparison struct {
+	Current  util.DateRange
+	Previous util.DateRange
+	Projects compareEntries
+	Files    compareEntries
+	Authors  compareEntries
+	Trend    trendEntries
+}
+
+func (c commitNoteDetails) compare(periods []util.DateRange) comparison {
+	buckets := c.byPeriod(periods)
+	current, previous := buckets[0], buckets[1]
+
+	projects := compareEntriesMap{}
+	authors := compareEntriesMap{}
+	files := compareEntriesMap{}
+	for i, b := range []commitNoteDetails{current, previous} {
+		for _, n := range b {
+			projects.add(n.Project, n.Project, n.Note.Total(), i == 1)
+			authors.add(strings.ToLower(n.Email), n.Author, n.Note.Total(), i == 1)
+		}
+		for _, f := range b.files() {
+			name := f.Filename
+			if f.IsApp() {
+				name = "[app] " + f.GetAppName()
+			}
+			files.add(f.Filename, name, f.Seconds, i == 1)
+		}
+	}
+
+	trend := trendEntries{}
+	for i := len(periods) - 1; i >= 0; i-- {
+		trend = append(trend, trendEntry{Period: periods[i], Seconds: buckets[i].Total()})
+	}
+
+	fileEntries := files.sorted()
+	if len(fileEntries) > compareTopFiles {
+		fileEntries = fileEntries[:compareTopFiles]
+	}
+
+	return comparison{
+		Current:  periods[0],
+		Previous: periods[1],
+		Projects: projects.sorted(),
+		Files:    fileEntries,
+		Authors:  authors.sorted(),
+		Trend:    trend,
+	}
+}
+
+// Sections returns the projects, files and authors compared
+func (c comparison) Sections() []compareSection {
+	return []compareSection{
+		{Title: "Projects", Entries: c.Projects},
+		{Title: "Files", Entries: c.Files},
+		{Title: "Authors", Entries: c.Authors},
+	}
+}
+
+type compareSection struct {
+	Title   string
+	Entries compareEntries
+}
+
+type compareEntriesMap map[string]*compareEntry
+
+func (m compareEntriesMap) add(key, name string, secs int, previous bool) {
+	entry, ok := m[key]
+	if !ok {
+		entry = &compareEntry{Name: name}
+		m[key] = entry
+	}
+	if previous {
+		entry.Previous += secs
+	} else {
+		entry.Current += secs
+	}
+}
+
+func (m compareEntriesMap) sorted() compareEntries {
+	entries := make(compareEntries, 0, len(m))
+	for _, e := range m {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		switch {
+		case entries[i].Current != entries[j].Current:
+			return entries[i].Current > entries[j].Current
+		case entries[i].Previous != entries[j].Previous:
+			return entries[i].Previous > entries[j].Previous
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+type compareEntries []compareEntry
+
+func (c compareEntries) Total() compareEntry {
+	total := compareEntry{Name: "Total"}
+	for _, e := range c {
+		total.Current += e.Current
+		total.Previous += e.Previous
+	}
+	return total
+}
+
+type compareEntry struct {
+	Name     string
+	Current  int
+	Previous int
+}
+
+// Delta returns the difference between the current and previous period as a signed duration
+func (c compareEntry) Delta() string {
+	d := c.Current - c.Previous
+	switch {
+	case d > 0:
+		return "+" + util.FormatDuration(d)
+	case d < 0:
+		return "-" + util.FormatDuration(-d)
+	}
+	return "0s"
+}
+
+// Change returns the percent change from the previous period
+func (c compareEntry) Change() string {
+	switch {
+	case c.Previous == 0 && c.Current == 0:
+		return "0%"
+	case c.Previous == 0:
+		return "new"
+	}
+	return fmt.Sprintf("%+.0f%%", float64(c.Current-c.Previous)/float64(c.Previous)*100)
+}
+
+type trendEntries []trendEntry
+
+func (t trendEntries) Max() int {
+	max := 0
+	for _, e := range t {
+		if e.Seconds > max {
+			max = e.Seconds
+		}
+	}
+	return max
+}
+
+type trendEntry struct {
+	Period  util.DateRange
+	Seconds int
+}
+
+func (t trendEntry) Duration() string {
+	return util.FormatDuration(t.Seconds)
+}
+
+// Bar returns a bar scaled to the longest bar in the trend
+func (t trendEntry) Bar(max int) string {
+	if max == 0 || t.Seconds == 0 {
+		return ""
+	}
+	return strings.Repeat("█", 1+t.Seconds*(trendWidth-1)/max)
+}
+
+// periodString returns a date range formatted as a string, i.e. Sun Mar 13 - Sat Mar 19 2016
+func periodString(d util.DateRange) string {
+	if d.Start.Year() == d.End.Year() {
+		return fmt.Sprintf("%s - %s", d.Start.Format("Mon Jan 02"), d.End.Format("Mon Jan 02 2006"))
+	}
+	return fmt.Sprintf("%s - %s", d.Start.Format("Mon Jan 02 2006"), d.End.Format("Mon Jan 02 2006"))
+}
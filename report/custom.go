@@ -0,0 +1,108 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/DEVELOPEST/gtm-core/scm"
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+// TemplateData is the data model passed to user-defined templates, see docs/templates.md
+type TemplateData struct {
+	// Notes are the commits reported on, newest first
+	Notes commitNoteDetails
+	// Files are the files with time spent, most time first
+	Files fileEntries
+	// Timeline is the time spent by day and hour
+	Timeline timelineEntries
+	// Projects are the projects with time spent, most time first
+	Projects projectEntries
+	// Total is the time spent in seconds
+	Total       int
+	BoldFormat  string
+	GreenFormat string
+}
+
+// Custom returns a report rendered with the user-defined template in options.Template
+func Custom(projects []ProjectCommits, options OutputOptions) (string, error) {
+	t, err := template.New("Custom").Funcs(funcMap).Parse(options.Template)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse template, %s", err)
+	}
+
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	timeline, err := notes.timeline()
+	if err != nil {
+		return "", err
+	}
+
+	b := new(bytes.Buffer)
+	cf := colorFormater{color: options.Color}
+	err = t.Execute(
+		b,
+		TemplateData{
+			Notes:       notes,
+			Files:       notes.files(),
+			Timeline:    timeline,
+			Projects:    notes.projects(),
+			Total:       notes.Total(),
+			BoldFormat:  cf.white(true),
+			GreenFormat: cf.green(false),
+		})
+	if err != nil {
+		return "", fmt.Errorf("Unable to execute template, %s", err)
+	}
+	return b.String(), nil
+}
+
+func (c commitNoteDetails) projects() projectEntries {
+	projectsMap := map[string]int{}
+	for _, n := range c {
+		projectsMap[n.Project] += n.Note.Total()
+	}
+
+	projects := make(projectEntries, 0, len(projectsMap))
+	for name, secs := range projectsMap {
+		projects = append(projects, projectEntry{Name: name, Seconds: secs})
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Seconds == projects[j].Seconds {
+			return projects[i].Name < projects[j].Name
+		}
+		return projects[i].Seconds > projects[j].Seconds
+	})
+	return projects
+}
+
+type projectEntries []projectEntry
+
+func (p projectEntries) Duration() string {
+	total := 0
+	for _, e := range p {
+		total += e.Seconds
+	}
+	return util.FormatDuration(total)
+}
+
+func (p projectEntry) Duration() string {
+	return util.FormatDuration(p.Seconds)
+}
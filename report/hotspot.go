@@ -0,0 +1,117 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+const (
+	// hotspotMinSeconds is the time spent on a file before it can be flagged as a hotspot
+	hotspotMinSeconds = 600
+	// hotspotMaxLinesPerHour is the change rate below which a file is flagged as a hotspot
+	hotspotMaxLinesPerHour = 10
+)
+
+// hotspots returns files ranked by time spent per line changed,
+// commits without time spent are counted if they change a file that has time spent
+func (c commitNoteDetails) hotspots() hotspotEntries {
+	// files are keyed by project, the same path in different projects is a different file
+	type hotspotKey struct{ project, file string }
+
+	hotspotsMap := map[hotspotKey]*hotspotEntry{}
+	for _, n := range c {
+		touched := map[string]bool{}
+		for _, f := range n.Note.Files {
+			fe := fileEntry{Filename: f.SourceFile}
+			if fe.IsTerminal() || fe.IsApp() {
+				continue
+			}
+			key := hotspotKey{n.Project, f.SourceFile}
+			entry, ok := hotspotsMap[key]
+			if !ok {
+				entry = &hotspotEntry{Filename: f.SourceFile, Project: n.Project}
+				hotspotsMap[key] = entry
+			}
+			entry.Seconds += f.TimeSpent
+			touched[f.SourceFile] = true
+		}
+		for file := range n.FileStats {
+			touched[file] = true
+		}
+
+		for file := range touched {
+			entry, ok := hotspotsMap[hotspotKey{n.Project, file}]
+			if !ok {
+				// files changed without time spent are not hotspots
+				continue
+			}
+			entry.Commits++
+			entry.Insertions += n.FileStats[file].Insertions
+			entry.Deletions += n.FileStats[file].Deletions
+		}
+	}
+
+	hotspots := make(hotspotEntries, 0, len(hotspotsMap))
+	for _, entry := range hotspotsMap {
+		hotspots = append(hotspots, *entry)
+	}
+	sort.Sort(sort.Reverse(hotspots))
+	return hotspots
+}
+
+type hotspotEntries []hotspotEntry
+
+func (h hotspotEntries) Len() int      { return len(h) }
+func (h hotspotEntries) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h hotspotEntries) Less(i, j int) bool {
+	if h[i].SecondsPerLine() == h[j].SecondsPerLine() {
+		return h[i].Seconds < h[j].Seconds
+	}
+	return h[i].SecondsPerLine() < h[j].SecondsPerLine()
+}
+
+type hotspotEntry struct {
+	Filename   string
+	Project    string
+	Seconds    int
+	Commits    int
+	Insertions int
+	Deletions  int
+}
+
+func (h hotspotEntry) Duration() string {
+	return util.FormatDuration(h.Seconds)
+}
+
+// Lines returns the number of lines inserted and deleted
+func (h hotspotEntry) Lines() int {
+	return h.Insertions + h.Deletions
+}
+
+// SecondsPerLine returns the time spent per line changed,
+// files without changes are ranked as if one line changed
+func (h hotspotEntry) SecondsPerLine() float64 {
+	if h.Lines() == 0 {
+		return float64(h.Seconds)
+	}
+	return float64(h.Seconds) / float64(h.Lines())
+}
+
+// LinesPerHour returns the lines changed per hour of time spent
+func (h hotspotEntry) LinesPerHour() string {
+	if h.Seconds == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%.0f", float64(h.Lines())/float64(h.Seconds)*3600)
+}
+
+// IsHotspot returns true if a lot of time resulted in little change
+func (h hotspotEntry) IsHotspot() bool {
+	return h.Seconds >= hotspotMinSeconds && float64(h.Lines())/float64(h.Seconds)*3600 < hotspotMaxLinesPerHour
+}
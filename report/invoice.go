@@ -0,0 +1,168 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/billing"
+	"github.com/DEVELOPEST/gtm-core/project"
+)
+
+const (
+	// OutputText is the default human readable report output
+	OutputText = "text"
+	// OutputCSV outputs comma separated values
+	OutputCSV = "csv"
+	// OutputJSON outputs JSON
+	OutputJSON = "json"
+)
+
+type invoiceKey struct {
+	period  time.Time
+	project string
+	email   string
+}
+
+// invoice returns the billable time by period, project and author,
+// time is rounded up to the billable increment per day
+func (c commitNoteDetails) invoice(rates billing.Rates, o TimesheetOptions, tags map[string][]string) invoice {
+	type dayKey struct {
+		day time.Time
+		invoiceKey
+	}
+
+	days := map[dayKey]int{}
+	names := map[string]string{}
+	for _, n := range c {
+		email := strings.ToLower(n.Email)
+		names[email] = n.Author
+		for _, f := range n.Note.Files {
+			for epoch, secs := range f.Timeline {
+				t := n.timeAt(epoch)
+				// days are the dates in the note's time zone at midnight UTC
+				day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+				period, _, _ := o.column(day)
+				days[dayKey{day: day, invoiceKey: invoiceKey{period: period, project: n.Project, email: email}}] += secs
+			}
+		}
+	}
+
+	linesMap := map[invoiceKey]*invoiceLine{}
+	for k, secs := range days {
+		line, ok := linesMap[k.invoiceKey]
+		if !ok {
+			period := k.period.Format("2006-01-02")
+			if o.Grid == TimesheetMonth {
+				period = k.period.Format("2006-01")
+			}
+			line = &invoiceLine{
+				Period:  period,
+				Project: k.project,
+				Author:  names[k.email],
+				Email:   k.email,
+				Rate:    rates.Rate(k.project, tags[k.project], names[k.email], k.email),
+			}
+			linesMap[k.invoiceKey] = line
+		}
+		line.Seconds += secs
+		line.BillableSeconds += rates.Billable(secs)
+	}
+
+	inv := invoice{Currency: rates.Currency, Lines: make([]invoiceLine, 0, len(linesMap))}
+	for _, line := range linesMap {
+		line.Hours = float64(line.BillableSeconds) / 3600
+		line.Amount = billing.Amount(line.BillableSeconds, line.Rate)
+		inv.Lines = append(inv.Lines, *line)
+		inv.Hours += line.Hours
+		inv.Amount += line.Amount
+	}
+	inv.Amount = math.Round(inv.Amount*100) / 100
+	sort.Slice(inv.Lines, func(i, j int) bool {
+		a, b := inv.Lines[i], inv.Lines[j]
+		switch {
+		case a.Period != b.Period:
+			return a.Period < b.Period
+		case a.Project != b.Project:
+			return a.Project < b.Project
+		}
+		return a.Email < b.Email
+	})
+	return inv
+}
+
+// projectTags returns the tags of each project by project name
+func projectTags(projects []ProjectCommits) map[string][]string {
+	tags := map[string][]string{}
+	for _, p := range projects {
+		t, err := project.LoadTags(filepath.Join(p.Path, project.GTMDir))
+		if err != nil {
+			continue
+		}
+		tags[filepath.Base(p.Path)] = t
+	}
+	return tags
+}
+
+type invoice struct {
+	Currency string        `json:"currency"`
+	Lines    []invoiceLine `json:"lines"`
+	Hours    float64       `json:"hours"`
+	Amount   float64       `json:"amount"`
+}
+
+// CSV returns the invoice lines as comma separated values with a header row
+func (i invoice) CSV() (string, error) {
+	b := new(bytes.Buffer)
+	w := csv.NewWriter(b)
+	records := [][]string{{"period", "project", "author", "email", "seconds", "billable_seconds", "hours", "rate", "amount", "currency"}}
+	for _, l := range i.Lines {
+		records = append(records, []string{
+			l.Period,
+			l.Project,
+			l.Author,
+			l.Email,
+			fmt.Sprintf("%d", l.Seconds),
+			fmt.Sprintf("%d", l.BillableSeconds),
+			fmt.Sprintf("%.2f", l.Hours),
+			fmt.Sprintf("%.2f", l.Rate),
+			fmt.Sprintf("%.2f", l.Amount),
+			i.Currency,
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// JSON returns the invoice as indented JSON
+func (i invoice) JSON() (string, error) {
+	b, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+type invoiceLine struct {
+	Period          string  `json:"period"`
+	Project         string  `json:"project"`
+	Author          string  `json:"author"`
+	Email           string  `json:"email"`
+	Seconds         int     `json:"seconds"`
+	BillableSeconds int     `json:"billableSeconds"`
+	Hours           float64 `json:"hours"`
+	Rate            float64 `json:"rate"`
+	Amount          float64 `json:"amount"`
+}
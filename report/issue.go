@@ -0,0 +1,135 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+// DefaultIssuePatterns match Jira style keys, i.e. PROJ-123, and GitHub/GitLab style keys, i.e. #456
+var DefaultIssuePatterns = []string{`\b[A-Z]{2}[A-Z0-9]*-[0-9]+\b`, `#[0-9]+\b`}
+
+// DefaultIssueStopList are the prefixes of words like UTF-8 or RFC-2119 that look like Jira keys,
+// they are skipped when the default patterns are used
+var DefaultIssueStopList = []string{"AES", "CVE", "ISO", "MD", "RFC", "SHA", "UTF"}
+
+const noIssue = "(none)"
+
+// IssueExtractor finds issue keys in commit subjects, messages and branches
+type IssueExtractor struct {
+	patterns []*regexp.Regexp
+	stop     []string
+}
+
+// NewIssueExtractor compiles the issue key patterns, DefaultIssuePatterns are used if none are provided
+func NewIssueExtractor(patterns []string) (IssueExtractor, error) {
+	e := IssueExtractor{}
+	if len(patterns) == 0 {
+		patterns = DefaultIssuePatterns
+		e.stop = DefaultIssueStopList
+	}
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return IssueExtractor{}, fmt.Errorf("Invalid issue pattern %s, %s", p, err)
+		}
+		e.patterns = append(e.patterns, re)
+	}
+	return e, nil
+}
+
+// Extract returns the unique issue keys found in texts in order of appearance
+func (e IssueExtractor) Extract(texts ...string) []string {
+	var keys []string
+	for _, t := range texts {
+		for _, re := range e.patterns {
+			for _, k := range re.FindAllString(t, -1) {
+				k = strings.ToUpper(k)
+				if e.stopped(k) {
+					continue
+				}
+				if !util.StringInSlice(keys, k) {
+					keys = append(keys, k)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+func (e IssueExtractor) stopped(key string) bool {
+	i := strings.LastIndex(key, "-")
+	return i > 0 && util.StringInSlice(e.stop, key[:i])
+}
+
+// Has returns true if the issue key is found in texts
+func (e IssueExtractor) Has(key string, texts ...string) bool {
+	return util.StringInSlice(e.Extract(texts...), strings.ToUpper(strings.TrimSpace(key)))
+}
+
+func (c commitNoteDetail) issueTexts() []string {
+	return []string{c.Subject, c.Message, c.Note.Branch}
+}
+
+func (c commitNoteDetails) issues(extractor IssueExtractor) issueEntries {
+	issuesMap := map[string]*issueEntry{}
+	for _, n := range c {
+		keys := extractor.Extract(n.issueTexts()...)
+		if len(keys) == 0 {
+			keys = []string{noIssue}
+		}
+		// commits that reference several issues count toward each of them
+		for _, k := range keys {
+			entry, ok := issuesMap[k]
+			if !ok {
+				entry = &issueEntry{Key: k}
+				issuesMap[k] = entry
+			}
+			entry.Seconds += n.Note.Total()
+			entry.Commits = append(entry.Commits, n)
+		}
+	}
+
+	issues := make(issueEntries, 0, len(issuesMap))
+	for _, entry := range issuesMap {
+		issues = append(issues, *entry)
+	}
+	sort.Sort(issues)
+	return issues
+}
+
+type issueEntries []issueEntry
+
+func (e issueEntries) Len() int      { return len(e) }
+func (e issueEntries) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+
+// Less orders by most time spent, commits without an issue are last
+func (e issueEntries) Less(i, j int) bool {
+	switch {
+	case e[i].Key == noIssue:
+		return false
+	case e[j].Key == noIssue:
+		return true
+	case e[i].Seconds == e[j].Seconds:
+		return e[i].Key < e[j].Key
+	}
+	return e[i].Seconds > e[j].Seconds
+}
+
+type issueEntry struct {
+	Key     string
+	Seconds int
+	Commits commitNoteDetails
+}
+
+func (e *issueEntry) Duration() string {
+	return util.FormatDuration(e.Seconds)
+}
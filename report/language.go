@@ -0,0 +1,254 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+const (
+	terminalLanguage = "Terminal"
+	appLanguage      = "Apps"
+	otherLanguage    = "Other"
+	// trendDays is the number of most recent days shown in the language trend
+	trendDays = 60
+)
+
+// languages is the built-in table of file extensions and well-known file names
+var languages = map[string]string{
+	".go":            "Go",
+	".mod":           "Go",
+	".sum":           "Go",
+	".py":            "Python",
+	".rb":            "Ruby",
+	".js":            "JavaScript",
+	".jsx":           "JavaScript",
+	".mjs":           "JavaScript",
+	".ts":            "TypeScript",
+	".tsx":           "TypeScript",
+	".java":          "Java",
+	".kt":            "Kotlin",
+	".scala":         "Scala",
+	".c":             "C",
+	".h":             "C",
+	".cc":            "C++",
+	".cpp":           "C++",
+	".hpp":           "C++",
+	".cs":            "C#",
+	".rs":            "Rust",
+	".swift":         "Swift",
+	".php":           "PHP",
+	".sh":            "Shell",
+	".bash":          "Shell",
+	".zsh":           "Shell",
+	".ps1":           "PowerShell",
+	".sql":           "SQL",
+	".html":          "HTML",
+	".htm":           "HTML",
+	".css":           "CSS",
+	".scss":          "CSS",
+	".less":          "CSS",
+	".vue":           "Vue",
+	".yaml":          "YAML",
+	".yml":           "YAML",
+	".json":          "JSON",
+	".toml":          "TOML",
+	".xml":           "XML",
+	".proto":         "Protocol Buffers",
+	".md":            "Markdown",
+	".rst":           "reStructuredText",
+	".txt":           "Text",
+	".tf":            "Terraform",
+	".vim":           "Vim script",
+	".lua":           "Lua",
+	"Dockerfile":     "Dockerfile",
+	"Makefile":       "Makefile",
+	"GNUmakefile":    "Makefile",
+	"Jenkinsfile":    "Groovy",
+	"Vagrantfile":    "Ruby",
+	"Gemfile":        "Ruby",
+	"Rakefile":       "Ruby",
+	".gitignore":     "Git config",
+	".gitattributes": "Git config",
+	".gitmodules":    "Git config",
+}
+
+// languageClassifier maps source files to languages,
+// custom patterns take precedence over the built-in table
+type languageClassifier struct {
+	custom map[string]string
+	globs  []languageGlob
+}
+
+type languageGlob struct {
+	pattern  string
+	language string
+}
+
+func newLanguageClassifier(custom map[string][]string) languageClassifier {
+	l := languageClassifier{custom: map[string]string{}}
+
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, p := range custom[name] {
+			if strings.ContainsAny(p, "/*?[") {
+				l.globs = append(l.globs, languageGlob{pattern: p, language: name})
+			} else {
+				l.custom[p] = name
+			}
+		}
+	}
+	return l
+}
+
+func (l languageClassifier) classify(f fileEntry) string {
+	switch {
+	case f.IsTerminal():
+		return terminalLanguage
+	case f.IsApp():
+		return appLanguage
+	}
+
+	base := path.Base(f.Filename)
+	ext := path.Ext(base)
+
+	for _, g := range l.globs {
+		if matched, err := path.Match(g.pattern, f.Filename); err == nil && matched {
+			return g.language
+		}
+		if matched, err := path.Match(g.pattern, base); err == nil && matched {
+			return g.language
+		}
+	}
+
+	for _, table := range []map[string]string{l.custom, languages} {
+		if lang, ok := table[base]; ok {
+			return lang
+		}
+		if lang, ok := table[ext]; ok && ext != "" {
+			return lang
+		}
+		if lang, ok := table[strings.ToLower(ext)]; ok && ext != "" {
+			return lang
+		}
+	}
+	return otherLanguage
+}
+
+// languages returns the time spent by language and the days covered by the trend lines,
+// there is no trend without timeline entries
+func (c commitNoteDetails) languages(custom map[string][]string) (languageEntries, []time.Time) {
+	classifier := newLanguageClassifier(custom)
+
+	languagesMap := map[string]*languageEntry{}
+	var lastDay time.Time
+	for _, n := range c {
+		for _, f := range n.Note.Files {
+			name := classifier.classify(fileEntry{Filename: f.SourceFile})
+			entry, ok := languagesMap[name]
+			if !ok {
+				entry = &languageEntry{Name: name, Days: map[string]int{}}
+				languagesMap[name] = entry
+			}
+			entry.Seconds += f.TimeSpent
+			for epoch, secs := range f.Timeline {
+				t := n.timeAt(epoch)
+				entry.Days[t.Format("2006-01-02")] += secs
+				if t.After(lastDay) {
+					lastDay = t
+				}
+			}
+		}
+	}
+
+	trendRange := make([]time.Time, 0, trendDays)
+	days := make([]string, 0, trendDays)
+	for i := trendDays - 1; i >= 0 && !lastDay.IsZero(); i-- {
+		d := lastDay.AddDate(0, 0, -i)
+		trendRange = append(trendRange, d)
+		days = append(days, d.Format("2006-01-02"))
+	}
+
+	max := 0
+	for _, entry := range languagesMap {
+		for _, d := range days {
+			if entry.Days[d] > max {
+				max = entry.Days[d]
+			}
+		}
+	}
+
+	entries := make(languageEntries, 0, len(languagesMap))
+	for _, entry := range languagesMap {
+		if len(days) > 0 {
+			trend := make([]int, len(days))
+			for i, d := range days {
+				trend[i] = entry.Days[d]
+			}
+			entry.Trend = sparkline(trend, max)
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Sort(sort.Reverse(entries))
+	return entries, trendRange
+}
+
+// sparkline returns one single width block per value scaled to max
+func sparkline(vals []int, max int) string {
+	blocks := []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	s := make([]rune, len(vals))
+	for i, v := range vals {
+		idx := 0
+		if max > 0 && v > 0 {
+			idx = 1 + v*(len(blocks)-2)/max
+		}
+		s[i] = blocks[idx]
+	}
+	return string(s)
+}
+
+type languageEntries []languageEntry
+
+func (l languageEntries) Len() int      { return len(l) }
+func (l languageEntries) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+func (l languageEntries) Less(i, j int) bool {
+	if l[i].Seconds == l[j].Seconds {
+		return l[i].Name > l[j].Name
+	}
+	return l[i].Seconds < l[j].Seconds
+}
+
+func (l languageEntries) Duration() string {
+	return util.FormatDuration(l.Total())
+}
+
+func (l languageEntries) Total() int {
+	total := 0
+	for _, entry := range l {
+		total += entry.Seconds
+	}
+	return total
+}
+
+type languageEntry struct {
+	Name    string
+	Seconds int
+	Days    map[string]int
+	Trend   string
+}
+
+func (l *languageEntry) Duration() string {
+	return util.FormatDuration(l.Seconds)
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+// DefaultPretty is the log format of the short hash, total time and subject
+const DefaultPretty = "%h %tT %s"
+
+// prettyPlaceholders are the git log style placeholders, the t placeholders are the time data
+var prettyPlaceholders = map[string]func(n commitNoteDetail) string{
+	"H":  func(n commitNoteDetail) string { return n.id },
+	"h":  func(n commitNoteDetail) string { return n.Hash },
+	"s":  func(n commitNoteDetail) string { return n.Subject },
+	"b":  func(n commitNoteDetail) string { return n.Message },
+	"an": func(n commitNoteDetail) string { return n.Author },
+	"ae": func(n commitNoteDetail) string { return n.Email },
+	"ad": func(n commitNoteDetail) string { return n.Date },
+	"n":  func(n commitNoteDetail) string { return "\n" },
+	"tT": func(n commitNoteDetail) string { return strings.TrimSpace(util.FormatDuration(n.Note.Total())) },
+	"ts": func(n commitNoteDetail) string { return strconv.Itoa(n.Note.Total()) },
+	"tF": func(n commitNoteDetail) string { return n.topFile() },
+	"tb": func(n commitNoteDetail) string { return n.Note.Branch },
+	"tp": func(n commitNoteDetail) string { return n.Project },
+}
+
+// pretty returns the commit formatted with the placeholders in format,
+// unknown placeholders are kept as is and %% is a percent sign
+func (n commitNoteDetail) pretty(format string) string {
+	b := new(bytes.Buffer)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if strings.HasPrefix(format[i+1:], "%") {
+			b.WriteByte('%')
+			i++
+			continue
+		}
+
+		replaced := false
+		for _, l := range []int{2, 1} {
+			if i+1+l > len(format) {
+				continue
+			}
+			if f, ok := prettyPlaceholders[format[i+1:i+1+l]]; ok {
+				b.WriteString(f(n))
+				i += l
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			b.WriteByte('%')
+		}
+	}
+	return b.String()
+}
+
+// topFile returns the file with the most time spent
+func (n commitNoteDetail) topFile() string {
+	top, max := "", 0
+	for _, f := range n.Note.Files {
+		if f.TimeSpent > max {
+			top, max = f.SourceFile, f.TimeSpent
+		}
+	}
+	return top
+}
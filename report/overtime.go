@@ -0,0 +1,136 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/workhours"
+)
+
+const (
+	// WithinWorkHours keeps only the time spent within working hours
+	WithinWorkHours = "within"
+	// OutsideWorkHours keeps only the time spent outside working hours
+	OutsideWorkHours = "outside"
+)
+
+// calendar returns the working hours in the note's time zone when reporting in a time zone,
+// otherwise in the calendar's time zone
+func (n commitNoteDetail) calendar(cal workhours.Calendar) workhours.Calendar {
+	if n.loc != nil {
+		return cal.In(n.loc)
+	}
+	return cal
+}
+
+// workHoursFilter returns a timeline filter keeping the time of the note within or outside working hours
+func (n commitNoteDetail) workHoursFilter(cal workhours.Calendar, keep string) func(epoch int64, secs int) int {
+	cal = n.calendar(cal)
+	switch keep {
+	case WithinWorkHours:
+		return func(epoch int64, secs int) int {
+			within, _ := cal.Split(n.timeAt(epoch), n.Note.Period(), secs)
+			return within
+		}
+	case OutsideWorkHours:
+		return func(epoch int64, secs int) int {
+			_, outside := cal.Split(n.timeAt(epoch), n.Note.Period(), secs)
+			return outside
+		}
+	}
+	return nil
+}
+
+// overtime returns the time spent within and outside working hours by week and author
+func (c commitNoteDetails) overtime(cal workhours.Calendar, weekStart time.Weekday) overtimeWeeks {
+	type key struct {
+		week  time.Time
+		email string
+	}
+
+	o := TimesheetOptions{Grid: TimesheetWeek, WeekStart: weekStart}
+	rowsMap := map[key]*overtimeRow{}
+	for _, n := range c {
+		email := strings.ToLower(n.Email)
+		cal := n.calendar(cal)
+		for _, f := range n.Note.Files {
+			for epoch, secs := range f.Timeline {
+				t := n.timeAt(epoch)
+				// weeks start on dates in the note's time zone at midnight UTC
+				week, _, _ := o.column(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+				k := key{week: week, email: email}
+				row, ok := rowsMap[k]
+				if !ok {
+					row = &overtimeRow{Author: n.Author}
+					rowsMap[k] = row
+				}
+				within, outside := cal.Split(t, n.Note.Period(), secs)
+				row.Within += within
+				row.Outside += outside
+			}
+		}
+	}
+
+	weeksMap := map[time.Time]*overtimeWeek{}
+	for k, row := range rowsMap {
+		week, ok := weeksMap[k.week]
+		if !ok {
+			week = &overtimeWeek{Start: k.week}
+			weeksMap[k.week] = week
+		}
+		week.Rows = append(week.Rows, *row)
+	}
+
+	weeks := make(overtimeWeeks, 0, len(weeksMap))
+	for _, w := range weeksMap {
+		sort.Slice(w.Rows, func(i, j int) bool {
+			if w.Rows[i].Outside != w.Rows[j].Outside {
+				return w.Rows[i].Outside > w.Rows[j].Outside
+			}
+			return w.Rows[i].Author < w.Rows[j].Author
+		})
+		weeks = append(weeks, *w)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].Start.Before(weeks[j].Start) })
+	return weeks
+}
+
+type overtimeWeeks []overtimeWeek
+
+type overtimeWeek struct {
+	Start time.Time
+	Rows  []overtimeRow
+}
+
+// Total returns the total of all authors in the week
+func (w overtimeWeek) Total() overtimeRow {
+	total := overtimeRow{Author: "Total"}
+	for _, r := range w.Rows {
+		total.Within += r.Within
+		total.Outside += r.Outside
+	}
+	return total
+}
+
+type overtimeRow struct {
+	Author  string
+	Within  int
+	Outside int
+}
+
+func (r overtimeRow) Total() int {
+	return r.Within + r.Outside
+}
+
+// OutsidePercent returns the percent of time spent outside working hours
+func (r overtimeRow) OutsidePercent() float64 {
+	if r.Total() == 0 {
+		return 0
+	}
+	return float64(r.Outside) / float64(r.Total()) * 100
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/DEVELOPEST/gtm-core/cache"
 	"github.com/DEVELOPEST/gtm-core/note"
 	"github.com/DEVELOPEST/gtm-core/project"
 	"github.com/DEVELOPEST/gtm-core/scm"
@@ -19,79 +20,200 @@ import (
 
 const (
 	defaultDateFormat = "Mon Jan 02 15:04:05 2006 MST"
+	// pendingHash and pendingSubject identify the synthetic entry of time not yet committed
+	pendingHash    = "pending"
+	pendingSubject = "uncommitted"
 )
 
 func retrieveNotes(projects []ProjectCommits,
-	terminalOff, appOff, calcStats bool,
-	dateFormat, subdir string) commitNoteDetails {
-	notes := commitNoteDetails{}
+	terminalOff, appOff bool, stats scm.StatsDetail,
+	dateFormat string, filter note.FileFilter) commitNoteDetails {
 
 	if dateFormat == "" {
 		dateFormat = defaultDateFormat
 	}
 
-	for _, p := range projects {
-		for _, c := range p.Commits {
-			n, err := scm.ReadNote(c, project.NoteNameSpace, calcStats, p.Path)
+	// projects are read concurrently, their notes are concatenated in project order
+	projectNotes := make([]commitNoteDetails, len(projects))
+	util.Parallel(len(projects), func(i int) {
+		projectNotes[i] = retrieveProjectNotes(projects[i], terminalOff, appOff, stats, dateFormat, filter)
+	})
+
+	notes := commitNoteDetails{}
+	for _, n := range projectNotes {
+		notes = append(notes, n...)
+	}
+	sort.Sort(notes)
+	return notes
+}
+
+// retrieveProjectNotes returns the notes of a project's commits and its pending time
+func retrieveProjectNotes(p ProjectCommits,
+	terminalOff, appOff bool, stats scm.StatsDetail,
+	dateFormat string, filter note.FileFilter) commitNoteDetails {
+	notes := commitNoteDetails{}
+
+	// the repository is opened once per project and diff stats are only calculated when needed
+	reader, readerErr := scm.NewNoteReader(project.NoteNameSpace, stats, p.Path)
+
+	var notesCache *cache.Cache
+	if readerErr == nil {
+		var err error
+		if notesCache, err = cache.Open(p.Path, reader); err != nil {
+			util.Debug.Printf("Unable to open cache for %s, %s", p.Path, err)
+		}
+	}
+
+	for _, c := range p.Commits {
+		if readerErr != nil {
+			notes = append(notes, commitNoteDetail{})
+			continue
+		}
+
+		var (
+			n          scm.CommitNote
+			commitNote note.CommitNote
+		)
+		if e, ok := notesCache.Get(c); ok && e.Stats >= stats {
+			n, commitNote = e.Commit, e.Note
+		} else {
+			var err error
+			n, err = reader.ReadUnmapped(c)
 			if err != nil {
 				notes = append(notes, commitNoteDetail{})
 				continue
 			}
 
-			when := n.When.Format(dateFormat)
-
-			var commitNote note.CommitNote
 			commitNote, err = note.UnMarshal(n.Note)
 			if err != nil {
 				commitNote = note.CommitNote{}
 			}
+			notesCache.Put(c, cache.Entry{Commit: n, Note: commitNote, Stats: stats})
+		}
+		// authors are cached as recorded in the commit, the .mailmap may have changed since
+		n = reader.ResolveAuthor(n)
 
-			if terminalOff {
-				commitNote = commitNote.FilterOutTerminal()
-			}
-			if appOff {
-				commitNote = commitNote.FilterOutApp()
-			}
+		when := n.When.Format(dateFormat)
 
-			if subdir != "" {
-				commitNote = commitNote.FilterOutSubdir(subdir)
-			}
+		if terminalOff {
+			commitNote = commitNote.FilterOutTerminal()
+		}
+		if appOff {
+			commitNote = commitNote.FilterOutApp()
+		}
+		commitNote = commitNote.FilterFiles(filter)
 
-			id := n.ID
-			if len(id) > 7 {
-				id = id[:7]
-			}
+		id := n.ID
+		if len(id) > 7 {
+			id = id[:7]
+		}
+
+		message := strings.TrimPrefix(n.Message, n.Summary)
+		message = strings.TrimSpace(message)
 
-			message := strings.TrimPrefix(n.Message, n.Summary)
-			message = strings.TrimSpace(message)
-
-			notes = append(notes,
-				commitNoteDetail{
-					Author:     n.Author,
-					Date:       when,
-					When:       n.When,
-					Hash:       id,
-					Subject:    n.Summary,
-					Message:    message,
-					Note:       commitNote,
-					Project:    filepath.Base(p.Path),
-					LineAdd:    fmt.Sprintf("+%d", n.Stats.Insertions),
-					LineDel:    fmt.Sprintf("-%d", n.Stats.Deletions),
-					LineDiff:   fmt.Sprintf("%d", n.Stats.Insertions-n.Stats.Deletions),
-					ChangeRate: fmt.Sprintf("%.0f", n.Stats.ChangeRatePerHour(commitNote.Total())),
-				})
+		notes = append(notes,
+			commitNoteDetail{
+				Author:     n.Author,
+				Email:      n.Email,
+				Date:       when,
+				When:       n.When,
+				Hash:       id,
+				Subject:    n.Summary,
+				Message:    message,
+				Note:       commitNote,
+				Project:    filepath.Base(p.Path),
+				LineAdd:    fmt.Sprintf("+%d", n.Stats.Insertions),
+				LineDel:    fmt.Sprintf("-%d", n.Stats.Deletions),
+				LineDiff:   fmt.Sprintf("%d", n.Stats.Insertions-n.Stats.Deletions),
+				ChangeRate: fmt.Sprintf("%.0f", n.Stats.ChangeRatePerHour(commitNote.Total())),
+				FileStats:  n.Stats.FileStats,
+				dateFormat: dateFormat,
+				id:         n.ID,
+				path:       p.Path,
+			})
+	}
+	if notesCache != nil {
+		if err := notesCache.Save(); err != nil {
+			util.Debug.Printf("Unable to save cache for %s, %s", p.Path, err)
+		}
+	}
+	if reader != nil {
+		reader.Free()
+	}
+
+	if p.Pending != nil {
+		if n, ok := pendingNote(*p.Pending, p.Path, terminalOff, appOff, dateFormat, filter); ok {
+			notes = append(notes, n)
 		}
 	}
-	sort.Sort(notes)
 	return notes
 }
 
+// pendingNote returns the time not yet committed as a synthetic commit by the git user dated now
+func pendingNote(pending note.CommitNote,
+	projPath string, terminalOff, appOff bool,
+	dateFormat string, filter note.FileFilter) (commitNoteDetail, bool) {
+
+	if terminalOff {
+		pending = pending.FilterOutTerminal()
+	}
+	if appOff {
+		pending = pending.FilterOutApp()
+	}
+	pending = pending.FilterFiles(filter)
+	if pending.Total() == 0 {
+		return commitNoteDetail{}, false
+	}
+
+	name, email := scm.GitUser(projPath)
+	when := util.Now()
+
+	return commitNoteDetail{
+		Author:     name,
+		Email:      email,
+		Date:       when.Format(dateFormat),
+		When:       when,
+		Hash:       pendingHash,
+		Subject:    pendingSubject,
+		Note:       pending,
+		Project:    filepath.Base(projPath),
+		LineAdd:    "+0",
+		LineDel:    "-0",
+		LineDiff:   "0",
+		ChangeRate: "0",
+		dateFormat: dateFormat,
+		id:         pendingHash,
+		path:       projPath,
+	}, true
+}
+
 type commitNoteDetails []commitNoteDetail
 
 func (c commitNoteDetails) Len() int           { return len(c) }
 func (c commitNoteDetails) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
 func (c commitNoteDetails) Less(i, j int) bool { return c[i].When.After(c[j].When) }
 
+// inZone shows the dates and timelines in the time zone loc or, if author is true,
+// in the time zone each note was recorded in
+func (c commitNoteDetails) inZone(loc *time.Location, author bool) {
+	for i := range c {
+		l := loc
+		if author {
+			if l = c[i].Note.Location(); l == nil {
+				l = c[i].When.Location()
+			}
+		}
+		if l == nil {
+			continue
+		}
+		c[i].loc = l
+		c[i].When = c[i].When.In(l)
+		if c[i].dateFormat != "" {
+			c[i].Date = c[i].When.Format(c[i].dateFormat)
+		}
+	}
+}
+
 func (c commitNoteDetails) Total() int {
 	t := 0
 	for i := range c {
@@ -102,6 +224,7 @@ func (c commitNoteDetails) Total() int {
 
 type commitNoteDetail struct {
 	Author     string
+	Email      string
 	Date       string
 	When       time.Time
 	Hash       string
@@ -113,6 +236,23 @@ type commitNoteDetail struct {
 	LineDel    string
 	LineDiff   string
 	ChangeRate string
+	FileStats  map[string]scm.FileStats
+
+	// dateFormat formats Date and loc is the time zone of timelines, nil for the local time zone
+	dateFormat string
+	loc        *time.Location
+	// id is the full commit hash and path is the project's repository path
+	id   string
+	path string
+}
+
+// timeAt returns the time of a timeline epoch in the note's time zone
+func (n commitNoteDetail) timeAt(epoch int64) time.Time {
+	t := time.Unix(epoch, 0)
+	if n.loc != nil {
+		return t.In(n.loc)
+	}
+	return t
 }
 
 func (c commitNoteDetails) files() fileEntries {
@@ -181,3 +321,207 @@ func (f *fileEntry) GetAppName() string {
 	name = util.UcFirst(name)
 	return name
 }
+
+func (c commitNoteDetails) branches() branchEntries {
+	branchesMap := map[string]branchEntry{}
+	for _, n := range c {
+		name := n.Note.Branch
+		if name == "" {
+			name = unknownBranch
+		}
+		entry, ok := branchesMap[name]
+		if !ok {
+			entry = branchEntry{Name: name, Projects: map[string]bool{}}
+		}
+		entry.Seconds += n.Note.Total()
+		entry.Commits++
+		entry.Projects[n.Project] = true
+		branchesMap[name] = entry
+	}
+
+	branches := make(branchEntries, 0, len(branchesMap))
+	for _, entry := range branchesMap {
+		branches = append(branches, entry)
+	}
+	sort.Sort(sort.Reverse(branches))
+	return branches
+}
+
+const unknownBranch = "(unknown)"
+
+type branchEntries []branchEntry
+
+func (b branchEntries) Len() int      { return len(b) }
+func (b branchEntries) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b branchEntries) Less(i, j int) bool {
+	if b[i].Seconds == b[j].Seconds {
+		return b[i].Name > b[j].Name
+	}
+	return b[i].Seconds < b[j].Seconds
+}
+
+func (b branchEntries) Duration() string {
+	return util.FormatDuration(b.Total())
+}
+
+func (b branchEntries) Total() int {
+	total := 0
+	for _, entry := range b {
+		total += entry.Seconds
+	}
+	return total
+}
+
+type branchEntry struct {
+	Name     string
+	Seconds  int
+	Commits  int
+	Projects map[string]bool
+}
+
+func (b *branchEntry) Duration() string {
+	return util.FormatDuration(b.Seconds)
+}
+
+// ProjectNames returns the sorted names of the projects the branch was committed to
+func (b *branchEntry) ProjectNames() string {
+	names := make([]string, 0, len(b.Projects))
+	for p := range b.Projects {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func (c commitNoteDetails) authors(teams project.Teams) authorEntries {
+	authorsMap := map[string]*authorEntry{}
+	for _, n := range c {
+		key := strings.ToLower(n.Email)
+		entry, ok := authorsMap[key]
+		if !ok {
+			// notes are sorted by most recent first, use the latest name for the author
+			entry = &authorEntry{
+				Name:     n.Author,
+				Email:    n.Email,
+				Teams:    teams.Of(n.Email),
+				Projects: map[string]int{}}
+			authorsMap[key] = entry
+		}
+		entry.Seconds += n.Note.Total()
+		entry.Commits++
+		entry.Projects[n.Project] += n.Note.Total()
+	}
+
+	authors := make(authorEntries, 0, len(authorsMap))
+	for _, entry := range authorsMap {
+		authors = append(authors, *entry)
+	}
+	sort.Sort(sort.Reverse(authors))
+	return authors
+}
+
+type authorEntries []authorEntry
+
+func (a authorEntries) Len() int      { return len(a) }
+func (a authorEntries) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a authorEntries) Less(i, j int) bool {
+	if a[i].Seconds == a[j].Seconds {
+		return a[i].Name > a[j].Name
+	}
+	return a[i].Seconds < a[j].Seconds
+}
+
+func (a authorEntries) Duration() string {
+	return util.FormatDuration(a.Total())
+}
+
+func (a authorEntries) Total() int {
+	total := 0
+	for _, entry := range a {
+		total += entry.Seconds
+	}
+	return total
+}
+
+// Teams returns the totals by team for authors that belong to a team
+func (a authorEntries) Teams() teamEntries {
+	teamsMap := map[string]*teamEntry{}
+	for _, author := range a {
+		for _, name := range author.Teams {
+			entry, ok := teamsMap[name]
+			if !ok {
+				entry = &teamEntry{Name: name}
+				teamsMap[name] = entry
+			}
+			entry.Seconds += author.Seconds
+			entry.Commits += author.Commits
+			entry.Members++
+		}
+	}
+
+	teams := make(teamEntries, 0, len(teamsMap))
+	for _, entry := range teamsMap {
+		teams = append(teams, *entry)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Seconds == teams[j].Seconds {
+			return teams[i].Name < teams[j].Name
+		}
+		return teams[i].Seconds > teams[j].Seconds
+	})
+	return teams
+}
+
+type authorEntry struct {
+	Name     string
+	Email    string
+	Seconds  int
+	Commits  int
+	Teams    []string
+	Projects map[string]int
+}
+
+func (a *authorEntry) Duration() string {
+	return util.FormatDuration(a.Seconds)
+}
+
+// TeamNames returns the author's teams as a comma separated list
+func (a *authorEntry) TeamNames() string {
+	return strings.Join(a.Teams, ",")
+}
+
+// TopProjects returns up to n projects the author spent the most time on
+func (a *authorEntry) TopProjects(n int) []projectEntry {
+	projects := make([]projectEntry, 0, len(a.Projects))
+	for name, secs := range a.Projects {
+		projects = append(projects, projectEntry{Name: name, Seconds: secs})
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Seconds == projects[j].Seconds {
+			return projects[i].Name < projects[j].Name
+		}
+		return projects[i].Seconds > projects[j].Seconds
+	})
+	if len(projects) > n {
+		projects = projects[:n]
+	}
+	return projects
+}
+
+type projectEntry struct {
+	Name    string
+	Seconds int
+}
+
+type teamEntries []teamEntry
+
+type teamEntry struct {
+	Name    string
+	Seconds int
+	Commits int
+	Members int
+}
+
+func (t *teamEntry) Duration() string {
+	return util.FormatDuration(t.Seconds)
+}
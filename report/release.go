@@ -0,0 +1,121 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+// releaseEntry is the time spent on the commits released by a tag
+type releaseEntry struct {
+	Tag          string
+	Project      string
+	When         time.Time
+	Seconds      int
+	Commits      int
+	Contributors map[string]int
+
+	// order is the position of the tag in the project's commit history, oldest first
+	order int
+}
+
+// Name returns the tag, commits not yet in a tag are unreleased
+func (r *releaseEntry) Name() string {
+	if r.Tag == "" {
+		return "unreleased"
+	}
+	return r.Tag
+}
+
+// Date returns the date of the tagged commit
+func (r *releaseEntry) Date() string {
+	if r.Tag == "" {
+		return ""
+	}
+	return r.When.Format("2006-01-02")
+}
+
+func (r *releaseEntry) Duration() string {
+	return util.FormatDuration(r.Seconds)
+}
+
+// TopContributors returns the authors of the release by time spent
+func (r *releaseEntry) TopContributors() []projectEntry {
+	contributors := make([]projectEntry, 0, len(r.Contributors))
+	for name, secs := range r.Contributors {
+		contributors = append(contributors, projectEntry{Name: name, Seconds: secs})
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Seconds == contributors[j].Seconds {
+			return contributors[i].Name < contributors[j].Name
+		}
+		return contributors[i].Seconds > contributors[j].Seconds
+	})
+	return contributors
+}
+
+type releaseEntries []releaseEntry
+
+func (r releaseEntries) Duration() string {
+	return util.FormatDuration(r.Total())
+}
+
+func (r releaseEntries) Total() int {
+	total := 0
+	for _, entry := range r {
+		total += entry.Seconds
+	}
+	return total
+}
+
+// releaseKey is a commit of a project, the same commit can be in several projects
+type releaseKey struct {
+	path string
+	id   string
+}
+
+// releases returns the time spent by release, released are the releases by project path and commit id
+// and commits not in a release are unreleased
+func (c commitNoteDetails) releases(released map[releaseKey]releaseEntry) releaseEntries {
+	releasesMap := map[string]*releaseEntry{}
+	for _, n := range c {
+		r := released[releaseKey{path: n.path, id: n.id}]
+		key := n.path + "\x00" + r.Tag
+		entry, ok := releasesMap[key]
+		if !ok {
+			entry = &releaseEntry{
+				Tag:          r.Tag,
+				Project:      n.Project,
+				When:         r.When,
+				Contributors: map[string]int{},
+				order:        r.order}
+			releasesMap[key] = entry
+		}
+		entry.Seconds += n.Note.Total()
+		entry.Commits++
+		entry.Contributors[n.Author] += n.Note.Total()
+	}
+
+	releases := make(releaseEntries, 0, len(releasesMap))
+	for _, entry := range releasesMap {
+		releases = append(releases, *entry)
+	}
+	// newest releases first by project, unreleased commits are the newest
+	sort.Slice(releases, func(i, j int) bool {
+		a, b := releases[i], releases[j]
+		switch {
+		case a.Project != b.Project:
+			return a.Project < b.Project
+		case a.Tag == "" || b.Tag == "":
+			return a.Tag == "" && b.Tag != ""
+		default:
+			return a.order > b.order
+		}
+	})
+	return releases
+}
@@ -12,10 +12,14 @@ import (
 	"runtime"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/DEVELOPEST/gtm-core/billing"
 	"github.com/DEVELOPEST/gtm-core/note"
 	"github.com/DEVELOPEST/gtm-core/project"
+	"github.com/DEVELOPEST/gtm-core/scm"
 	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/DEVELOPEST/gtm-core/workhours"
 	isatty "github.com/mattn/go-isatty"
 )
 
@@ -25,12 +29,17 @@ var funcMap = template.FuncMap{
 	"LeftPad2Len":    util.LeftPad2Len,
 	"Percent":        util.Percent,
 	"Blocks":         BlockForVal,
+	"Block":          func(val, max int) string { return blocksForVal(val, max, 1) },
+	"HoursMinutes":   hoursMinutes,
+	"Period":         periodString,
 }
 
-// ProjectCommits contains a project's directory path and commit ids
+// ProjectCommits contains a project's directory path, commit ids
+// and optionally the time not yet committed
 type ProjectCommits struct {
 	Path    string
 	Commits []string
+	Pending *note.CommitNote
 }
 
 // OutputOptions contains cli options for reporting
@@ -42,15 +51,64 @@ type OutputOptions struct {
 	AppOff       bool
 	Color        bool
 	Limit        int
+	Depth        int
 	Subdir       string
+	Include      []string
+	Exclude      []string
 	AutoLog      string
+	Issue        string
+	Issues       IssueExtractor
+	Timesheet    TimesheetOptions
+	Rates        billing.Rates
+	Periods      []util.DateRange
+	Template     string
+	Output       string
+	Teams        project.Teams
+	Languages    map[string][]string
+	// WorkHours is the working hours calendar, WorkHoursFilter keeps only the time within or outside of them
+	WorkHours       workhours.Calendar
+	WorkHoursFilter string
+	// Location is the time zone of dates and timelines, nil for the local time zone.
+	// AuthorTZ shows each commit in the time zone it was recorded in instead.
+	Location *time.Location
+	AuthorTZ bool
+	// Pretty is the git log style format of the log, i.e. %h %tT %s
+	Pretty string
+}
+
+// TZAuthor is the time zone option to show each commit in the time zone it was recorded in
+const TZAuthor = "author"
+
+// fileFilter returns the filter for the subdir, include and exclude options,
+// the work hours filter is applied by limitNotes once the notes are in their time zone
+func (o OutputOptions) fileFilter() note.FileFilter {
+	return note.FileFilter{
+		Subdir:  o.Subdir,
+		Include: o.Include,
+		Exclude: o.Exclude}
 }
 
 func (o OutputOptions) limitNotes(notes commitNoteDetails) commitNoteDetails {
 	ns := notes
+	if o.Issue != "" {
+		ns = commitNoteDetails{}
+		for _, n := range notes {
+			if o.Issues.Has(o.Issue, n.issueTexts()...) {
+				ns = append(ns, n)
+			}
+		}
+	}
 	if o.Limit > 0 && len(ns) > o.Limit {
 		ns = ns[0:o.Limit]
 	}
+	if o.Location != nil || o.AuthorTZ {
+		ns.inZone(o.Location, o.AuthorTZ)
+	}
+	if o.WorkHoursFilter != "" {
+		for i := range ns {
+			ns[i].Note = ns[i].Note.FilterFiles(note.FileFilter{Time: ns[i].workHoursFilter(o.WorkHours, o.WorkHoursFilter)})
+		}
+	}
 	return ns
 }
 
@@ -64,6 +122,7 @@ func Status(n note.CommitNote, options OutputOptions, projPath ...string) (strin
 	if options.AppOff {
 		n = n.FilterOutApp()
 	}
+	n = n.FilterFiles(options.fileFilter())
 
 	switch options.AutoLog {
 	case "gitlab":
@@ -122,9 +181,9 @@ func CommitSummary(projects []ProjectCommits, options OutputOptions) (string, er
 		retrieveNotes(projects,
 			options.TerminalOff,
 			options.AppOff,
-			false,
+			scm.StatsOff,
 			"Mon Jan 02",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -159,9 +218,9 @@ func ProjectSummary(projects []ProjectCommits, options OutputOptions) (string, e
 			projects,
 			options.TerminalOff,
 			options.AppOff,
-			false,
+			scm.StatsOff,
 			"Mon Jan 02",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -199,9 +258,9 @@ func Commits(projects []ProjectCommits, options OutputOptions) (string, error) {
 			projects,
 			options.TerminalOff,
 			options.AppOff,
-			true,
+			scm.StatsCommit,
 			"",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -236,9 +295,9 @@ func Timeline(projects []ProjectCommits, options OutputOptions) (string, error)
 			projects,
 			options.TerminalOff,
 			options.AppOff,
-			false,
+			scm.StatsOff,
 			"",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -277,9 +336,9 @@ func TimelineCommits(projects []ProjectCommits, options OutputOptions) (string,
 			projects,
 			options.TerminalOff,
 			options.AppOff,
-			false,
+			scm.StatsOff,
 			"",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -311,6 +370,96 @@ func TimelineCommits(projects []ProjectCommits, options OutputOptions) (string,
 	return b.String(), nil
 }
 
+// TimelineWeeks returns the time spent by week and weekday
+func TimelineWeeks(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+	return timelinePeriods(notes.timelineWeeks(options.Timesheet.WeekStart), options)
+}
+
+// TimelineMonths returns the time spent by month and day of the month
+func TimelineMonths(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+	return timelinePeriods(notes.timelineMonths(), options)
+}
+
+func timelinePeriods(timeline timelinePeriodEntries, options OutputOptions) (string, error) {
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Timeline").Funcs(funcMap).Parse(timelinePeriodTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Timeline    timelinePeriodEntries
+			BoldFormat  string
+			GreenFormat string
+		}{
+			timeline,
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// TimelineDays returns a calendar heatmap of the time spent by day with a column per week
+func TimelineDays(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Timeline").Funcs(funcMap).Parse(timelineHeatmapTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Heatmap     timelineHeatmap
+			BoldFormat  string
+			GreenFormat string
+		}{
+			notes.timelineHeatmap(options.Timesheet.WeekStart),
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 // Files returns the files report
 func Files(projects []ProjectCommits, options OutputOptions) (string, error) {
 	notes := options.limitNotes(
@@ -318,9 +467,9 @@ func Files(projects []ProjectCommits, options OutputOptions) (string, error) {
 			projects,
 			options.TerminalOff,
 			options.AppOff,
-			false,
+			scm.StatsOff,
 			"",
-			options.Subdir),
+			options.fileFilter()),
 	)
 	if len(notes) == 0 {
 		return "", nil
@@ -343,6 +492,531 @@ func Files(projects []ProjectCommits, options OutputOptions) (string, error) {
 
 }
 
+// Branches returns the time spent by branch report
+func Branches(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Branches").Funcs(funcMap).Parse(branchesTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Branches   branchEntries
+			BoldFormat string
+		}{
+			notes.branches(),
+			cf.white(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Authors returns the time spent by author report
+func Authors(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Authors").Funcs(funcMap).Parse(authorsTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Authors    authorEntries
+			BoldFormat string
+		}{
+			notes.authors(options.Teams),
+			cf.white(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Releases returns the time spent by release report, a commit is released by the first tag containing it
+func Releases(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	released := map[releaseKey]releaseEntry{}
+	for _, p := range projects {
+		releases, err := scm.Releases(p.Path)
+		if err != nil {
+			return "", err
+		}
+		for i, r := range releases {
+			for _, id := range r.Commits {
+				released[releaseKey{path: p.Path, id: id}] = releaseEntry{Tag: r.Tag, When: r.When, order: i}
+			}
+		}
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Releases").Funcs(funcMap).Parse(releasesTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Releases   releaseEntries
+			BoldFormat string
+		}{
+			notes.releases(released),
+			cf.white(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Tree returns the time spent rolled up by directory report
+func Tree(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Tree").Funcs(funcMap).Parse(treeTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Tree       treeLines
+			BoldFormat string
+		}{
+			notes.tree(options.Depth),
+			cf.white(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Languages returns the time spent by language and file type report
+func Languages(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	languages, trend := notes.languages(options.Languages)
+	trendStart, trendEnd := "", ""
+	if len(trend) > 0 {
+		trendStart, trendEnd = trend[0].Format("Mon Jan 02"), trend[len(trend)-1].Format("Mon Jan 02")
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Languages").Funcs(funcMap).Parse(languagesTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Languages   languageEntries
+			TrendStart  string
+			TrendEnd    string
+			BoldFormat  string
+			GreenFormat string
+		}{
+			languages,
+			trendStart,
+			trendEnd,
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Issues returns the time spent by issue key report
+func Issues(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Issues").Funcs(funcMap).Parse(issuesTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Issues      issueEntries
+			BoldFormat  string
+			GreenFormat string
+		}{
+			notes.issues(options.Issues),
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Timesheet returns the time spent by project and day report
+func Timesheet(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Timesheet").Funcs(funcMap).Parse(timesheetTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Timesheets  timesheets
+			BoldFormat  string
+			GreenFormat string
+		}{
+			notes.timesheets(options.Timesheet),
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Invoice returns the billable hours and amounts by period, project and author
+func Invoice(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	inv := notes.invoice(options.Rates, options.Timesheet, projectTags(projects))
+
+	switch options.Output {
+	case OutputCSV:
+		return inv.CSV()
+	case OutputJSON:
+		return inv.JSON()
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Invoice").Funcs(funcMap).Parse(invoiceTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Invoice     invoice
+			BoldFormat  string
+			GreenFormat string
+		}{
+			inv,
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Compare returns the time spent in the current period compared to the previous period
+// and the trend across all periods, options.Periods contains the periods with the current period first
+func Compare(projects []ProjectCommits, options OutputOptions) (string, error) {
+	if len(options.Periods) < 2 {
+		return "", fmt.Errorf("At least two periods are required to compare")
+	}
+
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Compare").Funcs(funcMap).Parse(compareTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Comparison  comparison
+			BoldFormat  string
+			GreenFormat string
+		}{
+			notes.compare(options.Periods),
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Hotspots returns the files ranked by time spent compared to lines changed and commits
+func Hotspots(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsFiles,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Hotspots").Funcs(funcMap).Parse(hotspotsTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Hotspots    hotspotEntries
+			BoldFormat  string
+			GreenFormat string
+			RedFormat   string
+		}{
+			notes.hotspots(),
+			cf.white(true),
+			cf.green(false),
+			cf.red(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Overtime returns the time spent within and outside working hours per week and author
+func Overtime(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Overtime").Funcs(funcMap).Parse(overtimeTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Weeks      overtimeWeeks
+			BoldFormat string
+			RedFormat  string
+		}{
+			notes.overtime(options.WorkHours, options.Timesheet.WeekStart),
+			cf.white(true),
+			cf.red(true),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Log returns a line per commit formatted with git log style placeholders, i.e. %h %tT %s
+func Log(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+
+	// git accepts the format with a format: or tformat: prefix
+	format := strings.TrimPrefix(strings.TrimPrefix(options.Pretty, "tformat:"), "format:")
+	if format == "" {
+		format = DefaultPretty
+	}
+
+	lines := []string{}
+	for _, n := range notes {
+		// commits that could not be read have no hash
+		if n.Hash == "" {
+			continue
+		}
+		lines = append(lines, n.pretty(format))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Blame returns the lines of a file with the time recorded for the file by the commits that last changed them
+// and a summary by commit, the lines are blamed in the project at projPath
+func Blame(projPath string, lines []scm.BlameLine, options OutputOptions) (string, error) {
+	commitIDs := []string{}
+	seen := map[string]bool{}
+	for _, l := range lines {
+		if !seen[l.CommitID] {
+			seen[l.CommitID] = true
+			commitIDs = append(commitIDs, l.CommitID)
+		}
+	}
+
+	notes := options.limitNotes(
+		retrieveNotes(
+			[]ProjectCommits{{Path: projPath, Commits: commitIDs}},
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			note.FileFilter{}),
+	)
+	blamed, commits := notes.blame(lines)
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Blame").Funcs(funcMap).Parse(blameTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Lines       []blameLine
+			Commits     blameCommits
+			BoldFormat  string
+			GreenFormat string
+		}{
+			blamed,
+			commits,
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Show returns the time spent by minute in each file of a commit
+func Show(projects []ProjectCommits, options OutputOptions) (string, error) {
+	notes := options.limitNotes(
+		retrieveNotes(
+			projects,
+			options.TerminalOff,
+			options.AppOff,
+			scm.StatsOff,
+			"",
+			options.fileFilter()),
+	)
+	if len(notes) == 0 {
+		return "", nil
+	}
+
+	b := new(bytes.Buffer)
+	t := template.Must(template.New("Show").Funcs(funcMap).Parse(showTpl))
+	cf := colorFormater{color: options.Color}
+	err := t.Execute(
+		b,
+		struct {
+			Detail      minuteDetail
+			BoldFormat  string
+			GreenFormat string
+		}{
+			notes[0].minuteDetail(),
+			cf.white(true),
+			cf.green(false),
+		})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 type colorFormater struct {
 	color bool
 }
@@ -373,12 +1047,25 @@ func (c colorFormater) green(bold bool) string {
 	return "%s"
 }
 
+func (c colorFormater) red(bold bool) string {
+	var attrBold int
+	if bold {
+		attrBold = 1
+	}
+	if c.hasColor() {
+		return fmt.Sprintf("\033[%d;%dm%%s\033[0m", attrBold, 31)
+	}
+	return "%s"
+}
+
 // BlockForVal determines the correct block to return for a value
 func BlockForVal(val, max int) string {
-	const (
-		blockCnt   int = 8
-		blockWidth int = 3
-	)
+	return blocksForVal(val, max, 3)
+}
+
+// blocksForVal returns the block for a value repeated blockWidth times
+func blocksForVal(val, max, blockWidth int) string {
+	const blockCnt int = 8
 
 	blocks := []string{`▁`, `▂`, `▃`, `▄`, `▅`, `▆`, `▇`, `█`}
 
@@ -0,0 +1,67 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+	"time"
+)
+
+// minuteFile is the time spent in a file of a commit by hour and minute
+type minuteFile struct {
+	SourceFile string
+	Status     string
+	Seconds    int
+	Hours      []minuteHour
+}
+
+// minuteHour is the time spent in an hour by minute
+type minuteHour struct {
+	Hour    string
+	Seconds int
+	Minutes [60]int
+}
+
+// minuteDetail is the per file minute timeline of a commit
+type minuteDetail struct {
+	Note  commitNoteDetail
+	Files []minuteFile
+	// Hourly is true for notes saved with hour resolution, only their hour totals are shown
+	Hourly bool
+}
+
+// minuteDetail returns the minute timelines of the note's files
+func (n commitNoteDetail) minuteDetail() minuteDetail {
+	d := minuteDetail{Note: n, Files: []minuteFile{}, Hourly: n.Note.Period() != time.Minute}
+
+	for _, f := range n.Note.Files {
+		file := minuteFile{SourceFile: f.SourceFile, Status: f.Status, Seconds: f.TimeSpent}
+		hours := map[time.Time]*minuteHour{}
+		for epoch, secs := range f.Timeline {
+			t := n.timeAt(epoch)
+			hour := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+			h, ok := hours[hour]
+			if !ok {
+				h = &minuteHour{Hour: hour.Format("Mon Jan 02 15:04")}
+				hours[hour] = h
+			}
+			h.Seconds += secs
+			if !d.Hourly {
+				h.Minutes[t.Minute()] += secs
+			}
+		}
+
+		keys := make([]time.Time, 0, len(hours))
+		for k := range hours {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+		for _, k := range keys {
+			file.Hours = append(file.Hours, *hours[k])
+		}
+		d.Files = append(d.Files, file)
+	}
+	return d
+}
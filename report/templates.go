@@ -93,6 +93,65 @@ const (
 	{{- printf "%92d" .Timeline.Total | printf $boldFormat }}
 {{ end }}`
 
+	timelinePeriodTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- $maxSecondsInDay := .Timeline.DayMaxSeconds }}
+{{- $rule := .Timeline.Rule }}
+{{printf "             %s" .Timeline.Header | printf $boldFormat }}
+{{printf "             %s" $rule | printf $boldFormat }}
+{{ range $_, $entry := .Timeline.Entries }}
+{{- printf "%-10s" $entry.Period | printf $boldFormat }} | {{ range $_, $d := .Days }}{{ Blocks $d $maxSecondsInDay | printf $greenFormat }}{{ end }} | {{ LeftPad2Len $entry.Duration " " 13 | printf $boldFormat }}
+{{ end }}
+{{- if len .Timeline.Entries }}
+	{{- printf "             %s" $rule | printf $boldFormat }}{{ printf "\n" }}
+	{{- LeftPad2Len .Timeline.Duration " " .Timeline.TotalWidth | printf $boldFormat }}
+{{ end }}`
+
+	timelineHeatmapTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- $maxSecondsInDay := .Heatmap.DayMaxSeconds }}
+{{printf "      %s" .Heatmap.Months | printf $boldFormat }}
+{{ range $_, $row := .Heatmap.Rows }}
+{{- printf $boldFormat $row.Weekday }} | {{ range $_, $d := $row.Days }}{{ Block $d $maxSecondsInDay | printf $greenFormat }}{{ end }}
+{{ end }}
+{{- printf "      %s" .Heatmap.Duration | printf $boldFormat }}
+`
+
+	showTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- with .Detail.Note }}
+{{ printf $boldFormat .Hash }} {{ printf $greenFormat .Subject }}
+{{ .Date }} {{ printf $boldFormat .Project }} {{ .Author }}
+{{- end }}
+{{- if not .Detail.Files }}
+
+No time recorded
+{{- else if .Detail.Hourly }}
+
+Time is recorded by hour, use gtm commit -resolution=minute or notes.resolution in config.json for minutes
+{{- end }}
+{{ range $_, $f := .Detail.Files }}
+{{ FormatDuration $f.Seconds | printf "%14s" }} [{{ $f.Status }}] {{ printf $boldFormat $f.SourceFile }}
+{{ printf $boldFormat "                   00   05   10   15   20   25   30   35   40   45   50   55" }}
+{{- range $_, $h := $f.Hours }}
+{{ printf $boldFormat $h.Hour }} | {{ range $_, $s := $h.Minutes }}{{ Block $s 60 | printf $greenFormat }}{{ end }} | {{ FormatDuration $h.Seconds | printf "%13s" }}
+{{- end }}
+{{ end }}`
+
+	blameTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- range $_, $l := .Lines }}
+{{ printf $boldFormat $l.Hash }} {{ printf "%-20.20s" $l.Author }} {{ FormatDuration $l.Seconds | printf "%14s" | printf $greenFormat }} {{ printf "%5d" $l.Line }}) {{ $l.Text }}
+{{- end }}
+{{ range $_, $c := .Commits }}
+{{ FormatDuration $c.Seconds | printf "%14s" | printf $greenFormat }} {{ printf "%5d" $c.Lines }} {{ if eq $c.Lines 1 }}line {{ else }}lines{{ end }} {{ printf $boldFormat $c.Hash }} {{ $c.Author }} {{ $c.Subject }}
+{{- end }}
+`
+
 	// TODO: determine left padding based on total hours
 	filesTpl string = `
 {{- $total := .Files.Total }}
@@ -105,5 +164,155 @@ const (
 {{ end }}
 {{- if len .Files }}
 	{{- .Files.Duration | printf "%14s" }}
+{{ end }}`
+
+	branchesTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $total := .Branches.Total }}
+{{ range $i, $b := .Branches }}
+	{{- $b.Duration | printf "%14s" }} {{ Percent $b.Seconds $total | printf "%3.0f"}}% {{ printf "%4d" $b.Commits }} {{ printf $boldFormat $b.Name }} [{{ $b.ProjectNames }}]
+{{ end }}
+{{- if len .Branches }}
+	{{- .Branches.Duration | printf "%14s" }}
+{{ end }}`
+
+	releasesTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $total := .Releases.Total }}
+{{ range $i, $r := .Releases }}
+	{{- $r.Duration | printf "%14s" }} {{ Percent $r.Seconds $total | printf "%3.0f"}}% {{ printf "%4d" $r.Commits }} {{ printf $boldFormat $r.Name }}{{ if $r.Date }} {{ $r.Date }}{{ end }} [{{ $r.Project }}]
+	{{- range $j, $c := $r.TopContributors }}
+		{{- if $j }},{{ else }}{{ printf "\n%24s" "" }}{{ end }} {{ $c.Name }} {{ Percent $c.Seconds $r.Seconds | printf "%.0f" }}%
+	{{- end }}
+{{ end }}
+{{- if len .Releases }}
+	{{- .Releases.Duration | printf "%14s" }}
+{{ end }}`
+
+	authorsTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $total := .Authors.Total }}
+{{ range $i, $a := .Authors }}
+	{{- $a.Duration | printf "%14s" }} {{ Percent $a.Seconds $total | printf "%3.0f"}}% {{ printf "%4d" $a.Commits }} {{ printf $boldFormat $a.Name }} <{{ $a.Email }}>
+	{{- if $a.Teams }} [{{ $a.TeamNames }}]{{ end }}
+	{{- range $j, $p := $a.TopProjects 3 }}
+		{{- if $j }},{{ else }}{{ printf "\n%24s" "" }}{{ end }} {{ $p.Name }} {{ Percent $p.Seconds $a.Seconds | printf "%.0f" }}%
+	{{- end }}
+{{ end }}
+{{- if len .Authors }}
+	{{- .Authors.Duration | printf "%14s" }}
+{{ end }}
+{{- $teams := .Authors.Teams }}
+{{- if len $teams }}
+{{ printf $boldFormat "Teams" }}
+{{ range $i, $t := $teams }}
+	{{- $t.Duration | printf "%14s" }} {{ Percent $t.Seconds $total | printf "%3.0f"}}% {{ printf "%4d" $t.Commits }} {{ printf $boldFormat $t.Name }} [{{ $t.Members }} members]
+{{ end }}
+{{- end }}`
+
+	treeTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{ range $i, $l := .Tree }}
+	{{- $l.Duration | printf "%14s" }} {{ Percent $l.Seconds $l.ParentSeconds | printf "%3.0f"}}%  {{ if $l.IsDir }}{{ printf $boldFormat $l.Path }}{{ else }}{{ $l.Path }}{{ end }}
+{{ end }}
+{{- if len .Tree }}
+	{{- .Tree.Duration | printf "%14s" }}
+{{ end }}`
+
+	languagesTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- $total := .Languages.Total }}
+{{- if .TrendStart }}
+{{ printf "%38s" "" }}{{ .TrendStart | printf "%-30s" | printf $boldFormat }}{{ .TrendEnd | printf "%30s" | printf $boldFormat }}
+{{- end }}
+{{ range $i, $l := .Languages }}
+	{{- $l.Duration | printf "%14s" }} {{ Percent $l.Seconds $total | printf "%3.0f"}}%  {{ RightPad2Len $l.Name " " 16 | printf $boldFormat }} {{ printf $greenFormat $l.Trend }}
+{{ end }}
+{{- if len .Languages }}
+	{{- .Languages.Duration | printf "%14s" }}
+{{ end }}`
+
+	issuesTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{ range $i, $e := .Issues }}
+	{{- $e.Duration | printf "%14s" }} {{ printf "%4d" (len $e.Commits) }} {{ printf $boldFormat $e.Key }}
+	{{- range $c := $e.Commits }}
+{{ $c.Note.Total | FormatDuration | printf "%18s" }} {{ printf "%.7s" $c.Hash }} {{ printf $greenFormat $c.Subject }} [{{ $c.Project }}]
+	{{- end }}
+{{ end }}`
+
+	timesheetTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{ range $_, $sheet := .Timesheets }}
+{{- $width := $sheet.NameWidth }}
+{{- printf $boldFormat $sheet.Title }}
+{{ printf "%-*s" $width "" }}{{ range $_, $c := $sheet.Columns }}{{ printf "%8s" $c | printf $boldFormat }}{{ end }}{{ printf "%8s" "Total" | printf $boldFormat }}
+{{ range $_, $row := $sheet.Rows }}
+	{{- printf "%-*s" $width $row.Project | printf $boldFormat }}
+	{{- range $_, $secs := $row.Cells }}{{ HoursMinutes $secs | printf "%8s" }}{{ end }}
+	{{- HoursMinutes $row.Total | printf "%8s" | printf $greenFormat }}
+{{ end }}
+{{- printf "%-*s" $width "Total" | printf $boldFormat }}
+{{- range $_, $secs := $sheet.ColumnTotals }}{{ HoursMinutes $secs | printf "%8s" | printf $greenFormat }}{{ end }}
+{{- HoursMinutes $sheet.Total | printf "%8s" | printf $boldFormat }}
+
+{{ end }}`
+
+	invoiceTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{ printf "%-10s %-20s %-24s %8s %10s %12s" "Period" "Project" "Author" "Hours" "Rate" "Amount" | printf $boldFormat }}
+{{ range $_, $l := .Invoice.Lines }}
+	{{- printf "%-10s %-20s %-24s %8.2f %10.2f" $l.Period $l.Project $l.Author $l.Hours $l.Rate }} {{ printf "%12.2f" $l.Amount | printf $greenFormat }}
+{{ end }}
+{{- printf "%-56s %8.2f %10s %12.2f" "Total" .Invoice.Hours "" .Invoice.Amount | printf $boldFormat }} {{ .Invoice.Currency }}
+`
+
+	compareTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- $max := .Comparison.Trend.Max }}
+{{ Period .Comparison.Current | printf $boldFormat }} compared to {{ Period .Comparison.Previous | printf $boldFormat }}
+{{ range $_, $s := .Comparison.Sections }}
+{{ printf "%14s %14s %16s %7s  %s" "Current" "Previous" "Delta" "Change" $s.Title | printf $boldFormat }}
+{{ range $_, $e := $s.Entries }}
+	{{- FormatDuration $e.Current | printf "%14s" }} {{ FormatDuration $e.Previous | printf "%14s" }} {{ printf "%16s" $e.Delta | printf $greenFormat }} {{ printf "%7s" $e.Change }}  {{ $e.Name }}
+{{ end }}
+{{- with $s.Entries.Total }}
+	{{- FormatDuration .Current | printf "%14s" | printf $boldFormat }} {{ FormatDuration .Previous | printf "%14s" | printf $boldFormat }} {{ printf "%16s" .Delta | printf $boldFormat }} {{ printf "%7s" .Change | printf $boldFormat }}
+{{ end }}
+{{- end }}
+{{ printf $boldFormat "Trend" }}
+{{ range $_, $t := .Comparison.Trend }}
+	{{- Period $t.Period | printf "%-30s" }} {{ $t.Duration | printf "%14s" }} {{ $t.Bar $max | printf $greenFormat }}
+{{ end }}`
+
+	hotspotsTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $greenFormat := .GreenFormat }}
+{{- $redFormat := .RedFormat }}
+{{ printf "%14s %7s %8s %8s %8s" "Time" "Commits" "Added" "Deleted" "Lines/hr" | printf $boldFormat }}
+{{ range $_, $h := .Hotspots }}
+	{{- $h.Duration | printf "%14s" }} {{ printf "%7d" $h.Commits }} {{ printf "+%d" $h.Insertions | printf "%8s" | printf $greenFormat }} {{ printf "-%d" $h.Deletions | printf "%8s" }} {{ printf "%8s" $h.LinesPerHour }} {{ if $h.IsHotspot }}{{ printf $redFormat "!" }}{{ else }} {{ end }} {{ $h.Filename }} [{{ $h.Project }}]
+{{ end }}
+{{- if len .Hotspots }}
+{{ printf $redFormat "!" }} little change for the time spent, worth a look for refactoring
+{{ end }}`
+
+	overtimeTpl string = `
+{{- $boldFormat := .BoldFormat }}
+{{- $redFormat := .RedFormat }}
+{{ range $_, $w := .Weeks }}
+{{- $w.Start.Format "Week of Mon Jan 02 2006" | printf $boldFormat }}
+{{ printf "%-24s %14s %14s %14s %8s" "Author" "Total" "Work hours" "After hours" "After %" | printf $boldFormat }}
+{{ range $_, $r := $w.Rows }}
+	{{- printf "%-24s" $r.Author }} {{ FormatDuration $r.Total | printf "%14s" }} {{ FormatDuration $r.Within | printf "%14s" }} {{ FormatDuration $r.Outside | printf "%14s" }} {{ if gt $r.OutsidePercent 20.0 }}{{ printf "%7.0f%%" $r.OutsidePercent | printf $redFormat }}{{ else }}{{ printf "%7.0f%%" $r.OutsidePercent }}{{ end }}
+{{ end }}
+{{- $t := $w.Total }}
+{{- printf "%-24s" $t.Author | printf $boldFormat }} {{ FormatDuration $t.Total | printf "%14s" }} {{ FormatDuration $t.Within | printf "%14s" }} {{ FormatDuration $t.Outside | printf "%14s" }} {{ printf "%7.0f%%" $t.OutsidePercent }}
+
 {{ end }}`
 )
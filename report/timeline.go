@@ -5,8 +5,10 @@
 package report
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DEVELOPEST/gtm-core/util"
@@ -83,7 +85,7 @@ func (c commitNoteDetails) timeline() (timelineEntries, error) {
 	for _, n := range c {
 		for _, f := range n.Note.Files {
 			for epoch, secs := range f.Timeline {
-				t := time.Unix(epoch, 0)
+				t := n.timeAt(epoch)
 				day := t.Format("2006-01-02")
 				hour, err := strconv.Atoi(t.Format("15"))
 				if err != nil {
@@ -152,3 +154,180 @@ func (t *timelineEntry) add(s int, hour int) {
 func (t *timelineEntry) Duration() string {
 	return util.FormatDuration(t.Seconds)
 }
+
+// dailySeconds returns the seconds spent by day, days are the dates in the notes' time zone at midnight UTC
+func (c commitNoteDetails) dailySeconds() map[time.Time]int {
+	days := map[time.Time]int{}
+	for _, n := range c {
+		for _, f := range n.Note.Files {
+			for epoch, secs := range f.Timeline {
+				t := n.timeAt(epoch)
+				days[time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)] += secs
+			}
+		}
+	}
+	return days
+}
+
+// dayRange returns the first and last day of the daily seconds
+func dayRange(days map[time.Time]int) (time.Time, time.Time) {
+	var first, last time.Time
+	for day := range days {
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	return first, last
+}
+
+// dayMaxSeconds returns the most seconds spent in a day, at least a working day of 8 hours
+func dayMaxSeconds(days map[time.Time]int) int {
+	max := 8 * 3600
+	for _, secs := range days {
+		if secs > max {
+			max = secs
+		}
+	}
+	return max
+}
+
+type timelinePeriodEntries struct {
+	// Header labels the day columns
+	Header  string
+	Entries []timelinePeriodEntry
+	// DayMaxSeconds is the maximum of the day blocks
+	DayMaxSeconds int
+}
+
+// Rule returns the line under the header
+func (t timelinePeriodEntries) Rule() string {
+	return strings.Repeat("-", len(t.Header))
+}
+
+// TotalWidth returns the width to right align the total with the entry durations
+func (t timelinePeriodEntries) TotalWidth() int {
+	return 13 + len(t.Header) + 3 + 13
+}
+
+func (t timelinePeriodEntries) Duration() string {
+	total := 0
+	for _, entry := range t.Entries {
+		total += entry.Seconds
+	}
+	return util.FormatDuration(total)
+}
+
+// timelinePeriodEntry is the time spent by day in a week or month
+type timelinePeriodEntry struct {
+	Period  string
+	Seconds int
+	Days    []int
+}
+
+func (t *timelinePeriodEntry) Duration() string {
+	return util.FormatDuration(t.Seconds)
+}
+
+// timelineWeeks returns the time spent by week and weekday from the first to the last week with time spent
+func (c commitNoteDetails) timelineWeeks(weekStart time.Weekday) timelinePeriodEntries {
+	days := c.dailySeconds()
+	header := ""
+	for i := 0; i < 7; i++ {
+		header += time.Weekday((int(weekStart) + i) % 7).String()[:2] + "."
+	}
+	timeline := timelinePeriodEntries{Header: header, Entries: []timelinePeriodEntry{}, DayMaxSeconds: dayMaxSeconds(days)}
+	if len(days) == 0 {
+		return timeline
+	}
+
+	first, last := dayRange(days)
+	week, _, _ := TimesheetOptions{Grid: TimesheetWeek, WeekStart: weekStart}.column(first)
+	for ; !week.After(last); week = week.AddDate(0, 0, 7) {
+		entry := timelinePeriodEntry{Period: week.Format("2006-01-02"), Days: make([]int, 7)}
+		for i := range entry.Days {
+			entry.Days[i] = days[week.AddDate(0, 0, i)]
+			entry.Seconds += entry.Days[i]
+		}
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+	return timeline
+}
+
+// timelineMonths returns the time spent by month and day of the month from the first to the last month with time spent
+func (c commitNoteDetails) timelineMonths() timelinePeriodEntries {
+	days := c.dailySeconds()
+	header := ""
+	for d := 1; d <= 31; d++ {
+		header += fmt.Sprintf("%02d.", d)
+	}
+	timeline := timelinePeriodEntries{Header: header, Entries: []timelinePeriodEntry{}, DayMaxSeconds: dayMaxSeconds(days)}
+	if len(days) == 0 {
+		return timeline
+	}
+
+	first, last := dayRange(days)
+	month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for ; !month.After(last); month = month.AddDate(0, 1, 0) {
+		// months have a column for each of 31 days, the days past the end of the month are empty
+		entry := timelinePeriodEntry{Period: month.Format("Jan 2006"), Days: make([]int, 31)}
+		for i := 0; i < month.AddDate(0, 1, -1).Day(); i++ {
+			entry.Days[i] = days[month.AddDate(0, 0, i)]
+			entry.Seconds += entry.Days[i]
+		}
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+	return timeline
+}
+
+// timelineHeatmap is a calendar of the time spent by day with a column per week and a row per weekday
+type timelineHeatmap struct {
+	// Months labels the weeks in which a month starts
+	Months        string
+	Rows          []timelineHeatmapRow
+	DayMaxSeconds int
+	Seconds       int
+}
+
+type timelineHeatmapRow struct {
+	Weekday string
+	Days    []int
+}
+
+func (t timelineHeatmap) Duration() string {
+	return util.FormatDuration(t.Seconds)
+}
+
+// timelineHeatmap returns the calendar heatmap from the first to the last week with time spent
+func (c commitNoteDetails) timelineHeatmap(weekStart time.Weekday) timelineHeatmap {
+	days := c.dailySeconds()
+	heatmap := timelineHeatmap{DayMaxSeconds: dayMaxSeconds(days)}
+	for i := 0; i < 7; i++ {
+		heatmap.Rows = append(heatmap.Rows, timelineHeatmapRow{Weekday: time.Weekday((int(weekStart) + i) % 7).String()[:3]})
+	}
+	if len(days) == 0 {
+		return heatmap
+	}
+
+	first, last := dayRange(days)
+	week, _, _ := TimesheetOptions{Grid: TimesheetWeek, WeekStart: weekStart}.column(first)
+	for col := 0; !week.After(last); col, week = col+1, week.AddDate(0, 0, 7) {
+		for i := range heatmap.Rows {
+			secs := days[week.AddDate(0, 0, i)]
+			heatmap.Rows[i].Days = append(heatmap.Rows[i].Days, secs)
+			heatmap.Seconds += secs
+		}
+
+		// label the first week and the weeks in which a month starts if the previous label leaves room
+		month := week
+		if end := week.AddDate(0, 0, 6); end.Day() <= 7 {
+			month = end
+		}
+		if (col == 0 || month != week) && (col == 0 || len(heatmap.Months) < col) {
+			heatmap.Months += strings.Repeat(" ", col-len(heatmap.Months)) + month.Format("Jan")
+		}
+	}
+	return heatmap
+}
@@ -0,0 +1,211 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// TimesheetWeek shows a grid per week with a column for each day
+	TimesheetWeek = "week"
+	// TimesheetMonth shows a grid per month with a column for each week
+	TimesheetMonth = "month"
+
+	// RoundNone does not round timesheet entries
+	RoundNone = "none"
+	// RoundNearest rounds timesheet entries to the nearest increment
+	RoundNearest = "nearest"
+	// RoundUp rounds timesheet entries up to the next increment
+	RoundUp = "up"
+
+	// DefaultIncrement is the rounding increment in minutes
+	DefaultIncrement = 15
+)
+
+// TimesheetOptions contains the grid period, first day of the week and rounding for timesheets
+type TimesheetOptions struct {
+	Grid      string
+	WeekStart time.Weekday
+	Rounding  Rounding
+}
+
+// Rounding rounds the time spent on a project per day to an increment
+type Rounding struct {
+	Mode      string
+	Increment int
+}
+
+// NewRounding validates the rounding mode and converts the increment from minutes to seconds
+func NewRounding(mode string, minutes int) (Rounding, error) {
+	if mode == "" {
+		mode = RoundNone
+	}
+	if mode != RoundNone && mode != RoundNearest && mode != RoundUp {
+		return Rounding{}, fmt.Errorf("Invalid rounding %s, must be none, nearest or up", mode)
+	}
+	if minutes == 0 {
+		minutes = DefaultIncrement
+	}
+	if minutes < 0 {
+		return Rounding{}, fmt.Errorf("Invalid rounding increment %d", minutes)
+	}
+	return Rounding{Mode: mode, Increment: minutes * 60}, nil
+}
+
+// Round returns seconds rounded to the increment
+func (r Rounding) Round(secs int) int {
+	if r.Increment <= 0 || secs == 0 {
+		return secs
+	}
+	switch r.Mode {
+	case RoundNearest:
+		return (secs + r.Increment/2) / r.Increment * r.Increment
+	case RoundUp:
+		return (secs + r.Increment - 1) / r.Increment * r.Increment
+	}
+	return secs
+}
+
+func (c commitNoteDetails) timesheets(o TimesheetOptions) timesheets {
+	// seconds by day and project, days are the dates in the notes' time zone at midnight UTC
+	// so notes with different time zones fall on the same day
+	days := map[time.Time]map[string]int{}
+	for _, n := range c {
+		for _, f := range n.Note.Files {
+			for epoch, secs := range f.Timeline {
+				t := n.timeAt(epoch)
+				day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+				if _, ok := days[day]; !ok {
+					days[day] = map[string]int{}
+				}
+				days[day][n.Project] += secs
+			}
+		}
+	}
+
+	sheetsMap := map[time.Time]*timesheet{}
+	rowsMap := map[time.Time]map[string]*timesheetRow{}
+	for day, projects := range days {
+		start, columns, col := o.column(day)
+		sheet, ok := sheetsMap[start]
+		if !ok {
+			sheet = &timesheet{Start: start, Columns: columns}
+			if o.Grid == TimesheetMonth {
+				sheet.Title = start.Format("January 2006")
+			} else {
+				sheet.Title = start.Format("Week of Mon Jan 02 2006")
+			}
+			sheetsMap[start] = sheet
+			rowsMap[start] = map[string]*timesheetRow{}
+		}
+		for name, secs := range projects {
+			row, ok := rowsMap[start][name]
+			if !ok {
+				row = &timesheetRow{Project: name, Cells: make([]int, len(columns))}
+				rowsMap[start][name] = row
+			}
+			row.Cells[col] += o.Rounding.Round(secs)
+		}
+	}
+
+	sheets := make(timesheets, 0, len(sheetsMap))
+	for start, sheet := range sheetsMap {
+		for _, row := range rowsMap[start] {
+			sheet.Rows = append(sheet.Rows, *row)
+		}
+		sort.Slice(sheet.Rows, func(i, j int) bool { return sheet.Rows[i].Project < sheet.Rows[j].Project })
+		sheets = append(sheets, *sheet)
+	}
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].Start.Before(sheets[j].Start) })
+	return sheets
+}
+
+// column returns the start of the grid containing day, the grid's column headings and day's column
+func (o TimesheetOptions) column(day time.Time) (time.Time, []string, int) {
+	if o.Grid == TimesheetMonth {
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		offset := (int(start.Weekday()) - int(o.WeekStart) + 7) % 7
+		daysInMonth := start.AddDate(0, 1, -1).Day()
+
+		columns := []string{}
+		for d := 1; d <= daysInMonth; d++ {
+			if d == 1 || (d-1+offset)%7 == 0 {
+				columns = append(columns, start.AddDate(0, 0, d-1).Format("Jan 02"))
+			}
+		}
+		return start, columns, (day.Day() - 1 + offset) / 7
+	}
+
+	offset := (int(day.Weekday()) - int(o.WeekStart) + 7) % 7
+	start := day.AddDate(0, 0, -offset)
+	columns := make([]string, 7)
+	for i := range columns {
+		columns[i] = start.AddDate(0, 0, i).Format("Mon 02")
+	}
+	return start, columns, offset
+}
+
+type timesheets []timesheet
+
+type timesheet struct {
+	Title   string
+	Start   time.Time
+	Columns []string
+	Rows    []timesheetRow
+}
+
+// ColumnTotals returns the total seconds of each column
+func (t timesheet) ColumnTotals() []int {
+	totals := make([]int, len(t.Columns))
+	for _, r := range t.Rows {
+		for i, secs := range r.Cells {
+			totals[i] += secs
+		}
+	}
+	return totals
+}
+
+func (t timesheet) Total() int {
+	total := 0
+	for _, r := range t.Rows {
+		total += r.Total()
+	}
+	return total
+}
+
+// NameWidth returns the width of the project name column
+func (t timesheet) NameWidth() int {
+	width := len("Total")
+	for _, r := range t.Rows {
+		if len(r.Project) > width {
+			width = len(r.Project)
+		}
+	}
+	return width
+}
+
+type timesheetRow struct {
+	Project string
+	Cells   []int
+}
+
+func (t timesheetRow) Total() int {
+	total := 0
+	for _, secs := range t.Cells {
+		total += secs
+	}
+	return total
+}
+
+// hoursMinutes formats seconds as hours and minutes, i.e. 1:15, zero is shown as -
+func hoursMinutes(secs int) string {
+	if secs == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%02d", secs/3600, secs%3600/60)
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+type treeNode struct {
+	Name     string
+	Seconds  int
+	IsDir    bool
+	Children map[string]*treeNode
+}
+
+func (t *treeNode) add(path []string, secs int) {
+	t.Seconds += secs
+	if len(path) == 0 {
+		return
+	}
+	if t.Children == nil {
+		t.Children = map[string]*treeNode{}
+	}
+	child, ok := t.Children[path[0]]
+	if !ok {
+		child = &treeNode{Name: path[0], IsDir: len(path) > 1}
+		t.Children[path[0]] = child
+	}
+	child.add(path[1:], secs)
+}
+
+func (t *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(t.Children))
+	for _, c := range t.Children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].Seconds == children[j].Seconds {
+			return children[i].Name < children[j].Name
+		}
+		return children[i].Seconds > children[j].Seconds
+	})
+	return children
+}
+
+// flatten walks the tree depth first up to maxDepth levels, 0 is no limit
+func (t *treeNode) flatten(depth, maxDepth int, lines treeLines) treeLines {
+	for _, c := range t.sortedChildren() {
+		lines = append(lines, treeLine{
+			Depth:         depth,
+			Name:          c.Name,
+			IsDir:         c.IsDir,
+			Seconds:       c.Seconds,
+			ParentSeconds: t.Seconds,
+		})
+		if c.IsDir && (maxDepth == 0 || depth+1 < maxDepth) {
+			lines = c.flatten(depth+1, maxDepth, lines)
+		}
+	}
+	return lines
+}
+
+func (c commitNoteDetails) tree(maxDepth int) treeLines {
+	root := &treeNode{IsDir: true}
+	for _, f := range c.files() {
+		if f.IsApp() {
+			root.add([]string{"[app] " + f.GetAppName()}, f.Seconds)
+			continue
+		}
+		root.add(strings.Split(f.Filename, "/"), f.Seconds)
+	}
+	return root.flatten(0, maxDepth, treeLines{})
+}
+
+type treeLines []treeLine
+
+func (t treeLines) Total() int {
+	total := 0
+	for _, l := range t {
+		if l.Depth == 0 {
+			total += l.Seconds
+		}
+	}
+	return total
+}
+
+func (t treeLines) Duration() string {
+	return util.FormatDuration(t.Total())
+}
+
+type treeLine struct {
+	Depth         int
+	Name          string
+	IsDir         bool
+	Seconds       int
+	ParentSeconds int
+}
+
+func (t treeLine) Duration() string {
+	return util.FormatDuration(t.Seconds)
+}
+
+// Path returns the indented name, directories end with a slash
+func (t treeLine) Path() string {
+	name := t.Name
+	if t.IsDir {
+		name += "/"
+	}
+	return strings.Repeat("  ", t.Depth) + name
+}
@@ -5,14 +5,17 @@
 package scm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -72,17 +75,30 @@ type CommitLimiter struct {
 	Author     string
 	Message    string
 	Subdir     string
+	Branch     string
+	NameSpace  string
+	Emails     []string
+	Revisions  []string
+	SinceTag   string
+	UntilTag   string
 	HasMax     bool
 	HasBefore  bool
 	HasAfter   bool
 	HasAuthor  bool
 	HasMessage bool
 	HasSubdir  bool
+	HasBranch  bool
+	HasEmails  bool
 }
 
-// NewCommitLimiter returns a new initialize CommitLimiter struct
+// NewCommitLimiter returns a new initialize CommitLimiter struct,
+// nameSpace is the git notes namespace used to read the branch recorded for a commit,
+// emails limits commits to authors with one of the email addresses
+// revisions are walked instead of HEAD using git rev-list syntax, i.e. main..feature, --branches, --all or ^v1.0
+// and sinceTag and untilTag limit commits to those after the since tag thru the until tag, i.e. v1.0..v1.1
 func NewCommitLimiter(
-	max int, fromDateStr, toDateStr, author, message string,
+	max int, fromDateStr, toDateStr, author, message, branch, nameSpace string, emails, revisions []string,
+	sinceTag, untilTag string,
 	today, yesterday, thisWeek, lastWeek,
 	thisMonth, lastMonth, thisYear, lastYear bool) (CommitLimiter, error) {
 
@@ -92,6 +108,13 @@ func NewCommitLimiter(
 	toDateStr = strings.TrimSpace(toDateStr)
 	author = strings.TrimSpace(author)
 	message = strings.TrimSpace(message)
+	branch = strings.TrimSpace(branch)
+
+	if branch != "" {
+		if _, err := path.Match(branch, ""); err != nil {
+			return CommitLimiter{}, fmt.Errorf("Invalid branch pattern %s, %s", branch, err)
+		}
+	}
 
 	cnt := func(vals []bool) int {
 		var c int
@@ -153,6 +176,8 @@ func NewCommitLimiter(
 
 	hasAuthor := author != ""
 	hasMessage := message != ""
+	hasBranch := branch != ""
+	hasEmails := len(emails) > 0
 	hasMax := max > 0
 
 	return CommitLimiter{
@@ -160,22 +185,99 @@ func NewCommitLimiter(
 		Max:        max,
 		Author:     author,
 		Message:    message,
+		Branch:     branch,
+		NameSpace:  nameSpace,
+		Emails:     emails,
+		Revisions:  util.Map(revisions, strings.TrimSpace),
+		SinceTag:   strings.TrimSpace(sinceTag),
+		UntilTag:   strings.TrimSpace(untilTag),
 		HasMax:     hasMax,
 		HasAuthor:  hasAuthor,
 		HasMessage: hasMessage,
+		HasBranch:  hasBranch,
+		HasEmails:  hasEmails,
 	}, nil
 }
 
-func (m CommitLimiter) filter(c *git.Commit, cnt int) (bool, bool, error) {
+// MatchBranch returns true if branch matches the limiter's branch glob pattern
+func (m CommitLimiter) MatchBranch(branch string) bool {
+	if !m.HasBranch {
+		return true
+	}
+	matched, err := path.Match(m.Branch, branch)
+	return err == nil && matched
+}
+
+// MatchUncommitted returns true if time not yet committed by the git user name and email on branch matches the limiter,
+// uncommitted time has no message and is only walked without revisions or an until tag
+func (m CommitLimiter) MatchUncommitted(name, email, branch string) bool {
+	switch {
+	case m.HasMessage, len(m.Revisions) > 0, m.UntilTag != "":
+		return false
+	case m.HasAuthor && !(strings.Contains(name, m.Author) || strings.Contains(email, m.Author)):
+		return false
+	case m.HasEmails && !m.matchEmail(email):
+		return false
+	}
+	return m.MatchBranch(branch)
+}
+
+var noteBranchRegex = regexp.MustCompile(`\[ver:\d+,total:\d+(?:,tz:[+-]\d{4})?(?:,res:m)?,branch:([^]]+)]`)
+
+// noteBranches returns the branches recorded in the headers of a git note
+func noteBranches(noteTxt string) []string {
+	var branches []string
+	for _, m := range noteBranchRegex.FindAllStringSubmatch(noteTxt, -1) {
+		branches = append(branches, m[1])
+	}
+	return branches
+}
+
+func (m CommitLimiter) filterBranch(c *git.Commit) bool {
+	n, err := c.Owner().Notes.Read("refs/notes/"+m.NameSpace, c.Id())
+	if err != nil {
+		return false
+	}
+	defer func() {
+		if err := n.Free(); err != nil {
+			fmt.Printf("Unable to free note, %s\n", err)
+		}
+	}()
+
+	for _, b := range noteBranches(n.Message()) {
+		if m.MatchBranch(b) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m CommitLimiter) matchEmail(email string) bool {
+	for _, e := range m.Emails {
+		if strings.EqualFold(strings.TrimSpace(e), email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m CommitLimiter) filter(c *git.Commit, mm *git.Mailmap, cnt int) (bool, bool, error) {
 	if m.HasMax && m.Max == cnt {
 		return false, true, nil
 	}
 
-	if m.DateRange.IsSet() && !m.DateRange.Within(c.Author().When) {
+	author := resolveAuthor(mm, c.Author())
+
+	if m.DateRange.IsSet() && !m.DateRange.Within(author.When) {
+		return false, false, nil
+	}
+
+	if m.HasAuthor &&
+		!(strings.Contains(author.Name, m.Author) || strings.Contains(author.Email, m.Author)) {
 		return false, false, nil
 	}
 
-	if m.HasAuthor && !strings.Contains(c.Author().Name, m.Author) {
+	if m.HasEmails && !m.matchEmail(author.Email) {
 		return false, false, nil
 	}
 
@@ -184,6 +286,10 @@ func (m CommitLimiter) filter(c *git.Commit, cnt int) (bool, bool, error) {
 		return false, false, nil
 	}
 
+	if m.HasBranch && !m.filterBranch(c) {
+		return false, false, nil
+	}
+
 	return true, false, nil
 }
 
@@ -214,16 +320,36 @@ func CommitIDs(limiter CommitLimiter, wd ...string) ([]string, error) {
 	}
 	defer w.Free()
 
-	err = w.PushHead()
+	// an until tag is walked instead of HEAD
+	if limiter.UntilTag == "" || len(limiter.Revisions) > 0 {
+		err = pushRevisions(repo, w, limiter.Revisions)
+		if err != nil {
+			return commits, err
+		}
+	}
+	err = pushTagRange(repo, w, limiter.SinceTag, limiter.UntilTag)
 	if err != nil {
 		return commits, err
 	}
 
+	mm := openMailmap(repo)
+	if mm != nil {
+		defer mm.Free()
+	}
+
 	var filterError error
 
+	// commits reachable from several revisions are only reported once
+	seen := map[string]bool{}
+
 	err = w.Iterate(
 		func(commit *git.Commit) bool {
-			include, done, err := limiter.filter(commit, cnt)
+			if seen[commit.Object.Id().String()] {
+				return true
+			}
+			seen[commit.Object.Id().String()] = true
+
+			include, done, err := limiter.filter(commit, mm, cnt)
 			if err != nil {
 				filterError = err
 				return false
@@ -248,6 +374,209 @@ func CommitIDs(limiter CommitLimiter, wd ...string) ([]string, error) {
 	return commits, nil
 }
 
+// pushRevisions adds revisions to the walk using git rev-list syntax, HEAD is walked if there are none.
+// Notes refs are never walked, --all includes branches, remote branches, tags and HEAD.
+func pushRevisions(repo *git.Repository, w *git.RevWalk, revisions []string) error {
+	if len(revisions) == 0 {
+		return w.PushHead()
+	}
+
+	// walk newest commits first when walking several refs so the commit limit applies to the latest
+	w.Sorting(git.SortTime)
+
+	globs := map[string]string{"--branches": "refs/heads/", "--tags": "refs/tags/", "--remotes": "refs/remotes/"}
+
+	for _, rev := range revisions {
+		var err error
+		opt := strings.SplitN(rev, "=", 2)
+
+		switch {
+		case rev == "":
+			continue
+		case rev == "--all":
+			for _, g := range []string{"refs/heads/*", "refs/remotes/*", "refs/tags/*"} {
+				if err = w.PushGlob(g); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = w.PushHead()
+			}
+		case globs[opt[0]] != "":
+			pattern := "*"
+			if len(opt) > 1 && opt[1] != "" {
+				pattern = opt[1]
+			}
+			err = w.PushGlob(globs[opt[0]] + pattern)
+		case strings.HasPrefix(rev, "-"):
+			err = fmt.Errorf("option not supported")
+		case strings.Contains(rev, ".."):
+			err = pushRange(repo, w, rev)
+		case strings.HasPrefix(rev, "^"):
+			var obj *git.Object
+			if obj, err = repo.RevparseSingle(rev[1:]); err == nil {
+				err = w.Hide(obj.Id())
+				obj.Free()
+			}
+		default:
+			var obj *git.Object
+			if obj, err = repo.RevparseSingle(rev); err == nil {
+				err = w.Push(obj.Id())
+				obj.Free()
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("Invalid revision %s, %s", rev, err)
+		}
+	}
+	return nil
+}
+
+// pushTagRange adds the commits thru the until tag to the walk and hides the commits thru the since tag,
+// empty tags are not added
+func pushTagRange(repo *git.Repository, w *git.RevWalk, sinceTag, untilTag string) error {
+	if untilTag != "" {
+		id, err := tagCommitID(repo, untilTag)
+		if err != nil {
+			return err
+		}
+		if err := w.Push(id); err != nil {
+			return err
+		}
+	}
+	if sinceTag != "" {
+		id, err := tagCommitID(repo, sinceTag)
+		if err != nil {
+			return err
+		}
+		return w.Hide(id)
+	}
+	return nil
+}
+
+// tagCommitID returns the id of the commit a tag refers to
+func tagCommitID(repo *git.Repository, tag string) (*git.Oid, error) {
+	obj, err := repo.RevparseSingle("refs/tags/" + tag)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid tag %s, %s", tag, err)
+	}
+	defer obj.Free()
+
+	commit, err := obj.Peel(git.ObjectCommit)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid tag %s, %s", tag, err)
+	}
+	defer commit.Free()
+
+	return commit.Id(), nil
+}
+
+// tagTime returns the tagger date of an annotated tag, false for lightweight tags
+func tagTime(repo *git.Repository, tag string) (time.Time, bool) {
+	obj, err := repo.RevparseSingle("refs/tags/" + tag)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer obj.Free()
+
+	if obj.Type() != git.ObjectTag {
+		return time.Time{}, false
+	}
+	t, err := obj.AsTag()
+	if err != nil || t.Tagger() == nil {
+		return time.Time{}, false
+	}
+	return t.Tagger().When, true
+}
+
+// versionLess returns true if version a is before b, numbers are compared by value
+// and a pre-release, i.e. v1.0-rc1, is before its release
+func versionLess(a, b string) bool {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+	if c := compareVersionParts(aCore, bCore); c != 0 {
+		return c < 0
+	}
+	if aPre == "" || bPre == "" {
+		return aPre != "" && bPre == ""
+	}
+	if c := compareVersionParts(aPre, bPre); c != 0 {
+		return c < 0
+	}
+	return a < b
+}
+
+// splitVersion returns the version without a leading v and its pre-release after the first dash
+func splitVersion(v string) (string, string) {
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	if i := strings.Index(v, "-"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+// compareVersionParts compares runs of digits by value and other runs as strings
+func compareVersionParts(a, b string) int {
+	for a != "" && b != "" {
+		aRun, aDigits := versionRun(a)
+		bRun, bDigits := versionRun(b)
+		a, b = a[len(aRun):], b[len(bRun):]
+
+		if aDigits && bDigits {
+			aNum, bNum := strings.TrimLeft(aRun, "0"), strings.TrimLeft(bRun, "0")
+			if len(aNum) != len(bNum) {
+				return len(aNum) - len(bNum)
+			}
+			aRun, bRun = aNum, bNum
+		}
+		if c := strings.Compare(aRun, bRun); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// versionRun returns the leading run of digits or non digits of s and true for digits
+func versionRun(s string) (string, bool) {
+	digits := s[0] >= '0' && s[0] <= '9'
+	i := 1
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == digits {
+		i++
+	}
+	return s[:i], digits
+}
+
+// pushRange adds a range to the walk, from..to walks commits reachable from to but not from
+// and from...to walks commits reachable from either but not from both
+func pushRange(repo *git.Repository, w *git.RevWalk, rev string) error {
+	spec, err := repo.Revparse(rev)
+	if err != nil {
+		return err
+	}
+	from, to := spec.From(), spec.To()
+	defer from.Free()
+	defer to.Free()
+
+	if err := w.Push(to.Id()); err != nil {
+		return err
+	}
+
+	if spec.Flags()&git.RevparseMergeBase == 0 {
+		return w.Hide(from.Id())
+	}
+
+	if err := w.Push(from.Id()); err != nil {
+		return err
+	}
+	base, err := repo.MergeBase(from.Id(), to.Id())
+	if err != nil {
+		// unrelated histories have no merge base
+		return nil
+	}
+	return w.Hide(base)
+}
+
 // Commit contains commit details
 type Commit struct {
 	ID      string
@@ -267,8 +596,32 @@ type CommitStats struct {
 	Insertions   int
 	Deletions    int
 	FilesChanged int
+	FileStats    map[string]FileStats
 }
 
+// FileStats contains the lines inserted and deleted in a file
+type FileStats struct {
+	Insertions int
+	Deletions  int
+}
+
+// Lines returns the number of lines changed
+func (f FileStats) Lines() int {
+	return f.Insertions + f.Deletions
+}
+
+// StatsDetail is how much of a commit's diff is read to calculate its stats
+type StatsDetail int
+
+const (
+	// StatsOff does not calculate diff stats
+	StatsOff StatsDetail = iota
+	// StatsCommit calculates the files changed and lines inserted and deleted by the commit
+	StatsCommit
+	// StatsFiles also calculates the lines inserted and deleted per file, the diff is read line by line
+	StatsFiles
+)
+
 // ChangeRatePerHour calculates the rate change per hour
 func (c CommitStats) ChangeRatePerHour(seconds int) float64 {
 	if seconds == 0 {
@@ -307,8 +660,37 @@ func CurrentBranch(wd ...string) string {
 	return branch
 }
 
-// DiffParentCommit compares commit to it's parent and returns their stats
-func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
+// GitUser returns the name and email of the git user configured for the repo
+func GitUser(wd ...string) (string, string) {
+	var (
+		repo *git.Repository
+		err  error
+		cfg  *git.Config
+	)
+
+	if len(wd) > 0 {
+		repo, err = openRepository(wd[0])
+	} else {
+		repo, err = openRepository()
+	}
+	if err != nil {
+		return "", ""
+	}
+	defer repo.Free()
+
+	if cfg, err = repo.Config(); err != nil {
+		return "", ""
+	}
+	defer cfg.Free()
+
+	name, _ := cfg.LookupString("user.name")
+	email, _ := cfg.LookupString("user.email")
+	return name, email
+}
+
+// DiffParentCommit compares commit to it's parent and returns their stats,
+// the lines changed per file are only counted if fileStats is true
+func DiffParentCommit(childCommit *git.Commit, fileStats bool) (CommitStats, error) {
 	defer util.Profile()()
 
 	childTree, err := childCommit.Tree()
@@ -323,6 +705,7 @@ func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
 		path := ""
 		fileCnt := 0
 		var files []string
+		filesStats := map[string]FileStats{}
 
 		err := childTree.Walk(
 			func(s string, entry *git.TreeEntry) error {
@@ -333,6 +716,14 @@ func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
 				default:
 					files = append(files, filepath.Join(path, entry.Name))
 					fileCnt++
+					if !fileStats {
+						break
+					}
+					// all lines of a file in the first commit are insertions
+					if blob, err := childCommit.Owner().LookupBlob(entry.Id); err == nil {
+						filesStats[filepath.ToSlash(s)+entry.Name] = FileStats{Insertions: countLines(blob.Contents())}
+						blob.Free()
+					}
 				}
 				return nil
 			})
@@ -346,6 +737,7 @@ func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
 			Deletions:    0,
 			Files:        files,
 			FilesChanged: fileCnt,
+			FileStats:    filesStats,
 		}, nil
 	}
 
@@ -371,18 +763,32 @@ func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
 	}()
 
 	var files []string
+	filesStats := map[string]FileStats{}
+	detail := git.DiffDetailFiles
+	if fileStats {
+		detail = git.DiffDetailLines
+	}
 	err = diff.ForEach(
 		func(delta git.DiffDelta, progress float64) (git.DiffForEachHunkCallback, error) {
 			// these should only be files that have changed
 
-			files = append(files, filepath.ToSlash(delta.NewFile.Path))
+			file := filepath.ToSlash(delta.NewFile.Path)
+			files = append(files, file)
 
 			return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
 				return func(line git.DiffLine) error {
+					stats := filesStats[file]
+					switch line.Origin {
+					case git.DiffLineAddition:
+						stats.Insertions++
+					case git.DiffLineDeletion:
+						stats.Deletions++
+					}
+					filesStats[file] = stats
 					return nil
 				}, nil
 			}, nil
-		}, git.DiffDetailFiles)
+		}, detail)
 
 	if err != nil {
 		return CommitStats{}, err
@@ -403,9 +809,21 @@ func DiffParentCommit(childCommit *git.Commit) (CommitStats, error) {
 		Deletions:    stats.Deletions(),
 		Files:        files,
 		FilesChanged: stats.FilesChanged(),
+		FileStats:    filesStats,
 	}, err
 }
 
+func countLines(b []byte) int {
+	if len(b) == 0 {
+		return 0
+	}
+	lines := bytes.Count(b, []byte("\n"))
+	if b[len(b)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
 // HeadCommit returns the latest commit
 func HeadCommit(wd ...string) (Commit, error) {
 	var (
@@ -433,7 +851,7 @@ func HeadCommit(wd ...string) (Commit, error) {
 	}
 	defer headCommit.Free()
 
-	commitStats, err := DiffParentCommit(headCommit)
+	commitStats, err := DiffParentCommit(headCommit, false)
 	if err != nil {
 		return commit, err
 	}
@@ -450,6 +868,238 @@ func HeadCommit(wd ...string) (Commit, error) {
 	}, nil
 }
 
+// RevisionCommitID returns the id of the commit a git revision refers to, i.e. HEAD~1, a tag or an abbreviated id
+func RevisionCommitID(rev string, wd ...string) (string, error) {
+	repo, err := openRepository(wd...)
+	if err != nil {
+		return "", err
+	}
+	defer repo.Free()
+
+	obj, err := repo.RevparseSingle(rev)
+	if err != nil {
+		return "", fmt.Errorf("Invalid revision %s, %s", rev, err)
+	}
+	defer obj.Free()
+
+	commit, err := obj.Peel(git.ObjectCommit)
+	if err != nil {
+		return "", fmt.Errorf("Invalid revision %s, %s", rev, err)
+	}
+	defer commit.Free()
+
+	return commit.Id().String(), nil
+}
+
+// Release is a tag and the commits it released
+type Release struct {
+	// Tag is empty for the commits of HEAD not yet in a tag
+	Tag     string
+	When    time.Time
+	Commits []string
+}
+
+// Releases returns the tags oldest first with the commits they released followed by the unreleased commits of HEAD,
+// a commit is released by the first tag containing it and tags are ordered by their commit history.
+// Tags of the same commit are ordered by tagger date and otherwise by version, i.e. v1.0-rc1 before v1.0.
+func Releases(wd ...string) ([]Release, error) {
+	repo, err := openRepository(wd...)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Free()
+
+	names, err := repo.Tags.List()
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(names)+1)
+	ids := map[string]*git.Oid{}
+	// tagged are the tag names by the id of their commit
+	tagged := map[string][]string{}
+	// tagTimes are the tagger dates of annotated tags
+	tagTimes := map[string]time.Time{}
+	for _, name := range names {
+		id, err := tagCommitID(repo, name)
+		if err != nil {
+			// tags of trees and blobs are not releases
+			continue
+		}
+		ids[name] = id
+		tagged[id.String()] = append(tagged[id.String()], name)
+		if when, ok := tagTime(repo, name); ok {
+			tagTimes[name] = when
+		}
+	}
+
+	// order the tags by walking their commits parents first
+	order := []string{}
+	if len(ids) > 0 {
+		w, err := repo.Walk()
+		if err != nil {
+			return nil, err
+		}
+		defer w.Free()
+		w.Sorting(git.SortTopological | git.SortReverse)
+		for _, id := range ids {
+			if err := w.Push(id); err != nil {
+				return nil, err
+			}
+		}
+		err = w.Iterate(func(commit *git.Commit) bool {
+			tags := tagged[commit.Id().String()]
+			sort.Slice(tags, func(i, j int) bool {
+				a, b := tagTimes[tags[i]], tagTimes[tags[j]]
+				if !a.IsZero() && !b.IsZero() && !a.Equal(b) {
+					return a.Before(b)
+				}
+				return versionLess(tags[i], tags[j])
+			})
+			order = append(order, tags...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	walkRelease := func(r *Release, push func(w *git.RevWalk) error, hide []string) error {
+		w, err := repo.Walk()
+		if err != nil {
+			return err
+		}
+		defer w.Free()
+		if err := push(w); err != nil {
+			return err
+		}
+		for _, tag := range hide {
+			if err := w.Hide(ids[tag]); err != nil {
+				return err
+			}
+		}
+		return w.Iterate(func(commit *git.Commit) bool {
+			r.Commits = append(r.Commits, commit.Id().String())
+			return true
+		})
+	}
+
+	for i, tag := range order {
+		commit, err := repo.LookupCommit(ids[tag])
+		if err != nil {
+			return nil, err
+		}
+		r := Release{Tag: tag, When: commit.Committer().When}
+		commit.Free()
+
+		push := func(w *git.RevWalk) error { return w.Push(ids[tag]) }
+		if err := walkRelease(&r, push, order[:i]); err != nil {
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	unborn, err := repo.IsHeadUnborn()
+	if err != nil {
+		return nil, err
+	}
+	if !unborn {
+		r := Release{}
+		if err := walkRelease(&r, func(w *git.RevWalk) error { return w.PushHead() }, order); err != nil {
+			return nil, err
+		}
+		if len(r.Commits) > 0 {
+			releases = append(releases, r)
+		}
+	}
+
+	return releases, nil
+}
+
+// BlameLine is a line of a file and the commit that last changed it
+type BlameLine struct {
+	Line     int
+	Text     string
+	CommitID string
+	// Path is the file path in the commit, it differs from the blamed path if the file was renamed
+	Path   string
+	Author string
+	Email  string
+	When   time.Time
+}
+
+// Blame returns the commit that last changed each line of a file in the head commit,
+// the file path is relative to the repository root with forward slashes
+func Blame(filePath string, wd ...string) ([]BlameLine, error) {
+	repo, err := openRepository(wd...)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Free()
+
+	head, err := lookupCommit(repo)
+	if err != nil {
+		return nil, err
+	}
+	defer head.Free()
+
+	tree, err := head.Tree()
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Free()
+
+	entry, err := tree.EntryByPath(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to blame %s, file is not in the head commit", filePath)
+	}
+	blob, err := repo.LookupBlob(entry.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Free()
+	text := strings.Split(strings.TrimSuffix(string(blob.Contents()), "\n"), "\n")
+
+	opts, err := git.DefaultBlameOptions()
+	if err != nil {
+		return nil, err
+	}
+	blame, err := repo.BlameFile(filePath, &opts)
+	if err != nil {
+		return nil, err
+	}
+	defer blame.Free()
+
+	mm := openMailmap(repo)
+	if mm != nil {
+		defer mm.Free()
+	}
+
+	lines := []BlameLine{}
+	for i := 0; i < blame.HunkCount(); i++ {
+		hunk, err := blame.HunkByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		author := resolveAuthor(mm, hunk.FinalSignature)
+		for l := 0; l < int(hunk.LinesInHunk); l++ {
+			line := BlameLine{
+				Line:     int(hunk.FinalStartLineNumber) + l,
+				CommitID: hunk.FinalCommitId.String(),
+				Path:     hunk.OrigPath,
+				Author:   author.Name,
+				Email:    author.Email,
+				When:     author.When,
+			}
+			if line.Line-1 < len(text) {
+				line.Text = strings.TrimSuffix(text[line.Line-1], "\r")
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
 // CreateNote creates a git note associated with the head commit
 func CreateNote(noteTxt, nameSpace, commitHash string, wd ...string) error {
 	defer util.Profile()()
@@ -559,13 +1209,11 @@ type CommitNote struct {
 
 // ReadNote returns a commit note for the SHA1 commit id,
 // tries to fetch squashed commits notes as well by message
-func ReadNote(commitID string, nameSpace string, calcStats bool, wd ...string) (CommitNote, error) {
+func ReadNote(commitID string, nameSpace string, stats StatsDetail, wd ...string) (CommitNote, error) {
 	var (
 		err    error
 		repo   *git.Repository
 		commit *git.Commit
-		n      *git.Note
-		notes  [][]string
 	)
 
 	if len(wd) > 0 {
@@ -582,72 +1230,268 @@ func ReadNote(commitID string, nameSpace string, calcStats bool, wd ...string) (
 		if commit != nil {
 			commit.Free()
 		}
-		if n != nil {
+		repo.Free()
+	}()
+
+	id, err := git.NewOid(commitID)
+	if err != nil {
+		return CommitNote{}, err
+	}
+
+	commit, err = repo.LookupCommit(id)
+	if err != nil {
+		return CommitNote{}, err
+	}
+
+	readNote := func(id *git.Oid) (string, bool) {
+		n, err := repo.Notes.Read("refs/notes/"+nameSpace, id)
+		if err != nil {
+			return "", false
+		}
+		defer func() {
 			if err := n.Free(); err != nil {
 				fmt.Printf("Unable to free note, %s\n", err)
 			}
+		}()
+		return n.Message(), true
+	}
+
+	mm := openMailmap(repo)
+	if mm != nil {
+		defer mm.Free()
+	}
+
+	return commitNote(commit, mm, stats, readNote)
+}
+
+// NoteReader reads the notes of many commits with a single repository session,
+// the notes ref is iterated once instead of looking up the note of each commit
+type NoteReader struct {
+	repo  *git.Repository
+	mm    *git.Mailmap
+	stats StatsDetail
+	// notesOID is the commit the notes ref points to, nil if there are no notes
+	notesOID *git.Oid
+	// notes maps annotated commit ids to note blob ids
+	notes map[string]*git.Oid
+}
+
+// NewNoteReader opens the repository and indexes the notes in nameSpace,
+// diff stats are calculated with the detail of stats
+func NewNoteReader(nameSpace string, stats StatsDetail, wd ...string) (*NoteReader, error) {
+	var (
+		repo *git.Repository
+		err  error
+	)
+
+	if len(wd) > 0 {
+		repo, err = openRepository(wd[0])
+	} else {
+		repo, err = openRepository()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &NoteReader{
+		repo:  repo,
+		mm:    openMailmap(repo),
+		stats: stats,
+		notes: map[string]*git.Oid{},
+	}
+
+	ref, err := repo.References.Lookup("refs/notes/" + nameSpace)
+	if err != nil {
+		// the notes ref does not exist until the first note is saved
+		return r, nil
+	}
+	r.notesOID = ref.Target()
+	ref.Free()
+
+	it, err := repo.NewNoteIterator("refs/notes/" + nameSpace)
+	if err != nil {
+		return r, nil
+	}
+	defer func() {
+		if err := it.Free(); err != nil {
+			fmt.Printf("Unable to free note iterator, %s\n", err)
 		}
-		repo.Free()
 	}()
 
+	for {
+		noteID, commitID, err := it.Next()
+		if err != nil {
+			break
+		}
+		r.notes[commitID.String()] = noteID
+	}
+	return r, nil
+}
+
+// Read returns the commit note for the SHA1 commit id,
+// including the notes of squashed commits referenced in the message
+func (r *NoteReader) Read(commitID string) (CommitNote, error) {
+	n, err := r.ReadUnmapped(commitID)
+	if err != nil {
+		return CommitNote{}, err
+	}
+	return r.ResolveAuthor(n), nil
+}
+
+// ReadUnmapped returns the commit note like Read with the author as recorded in the commit,
+// the author is mapped with ResolveAuthor
+func (r *NoteReader) ReadUnmapped(commitID string) (CommitNote, error) {
 	id, err := git.NewOid(commitID)
 	if err != nil {
 		return CommitNote{}, err
 	}
 
-	commit, err = repo.LookupCommit(id)
+	commit, err := r.repo.LookupCommit(id)
 	if err != nil {
 		return CommitNote{}, err
 	}
+	defer commit.Free()
 
-	r := regexp.MustCompile(`commit\s+([\dabcdef]*)\r?\n`)
-	msg := commit.Message()
-	notes = r.FindAllStringSubmatch(msg, -1)
+	return commitNote(commit, nil, r.stats, r.readNote)
+}
+
+// ResolveAuthor returns the commit note with its author mapped to the canonical identity in the .mailmap
+func (r *NoteReader) ResolveAuthor(n CommitNote) CommitNote {
+	if r.mm == nil {
+		return n
+	}
+	if name, email, err := r.mm.Resolve(n.Author, n.Email); err == nil {
+		n.Author, n.Email = name, email
+	}
+	return n
+}
 
-	var noteTxt string
-	n, err = repo.Notes.Read("refs/notes/"+nameSpace, id)
+// NotesOID returns the commit id the notes ref points to, empty if there are no notes
+func (r *NoteReader) NotesOID() string {
+	if r.notesOID == nil {
+		return ""
+	}
+	return r.notesOID.String()
+}
+
+// NotesDescendantOf returns true if the notes ref is the same as or descends from the notes commit id,
+// false means the notes ref was rewritten since the id was read
+func (r *NoteReader) NotesDescendantOf(notesOID string) bool {
+	if r.notesOID == nil || notesOID == "" {
+		return false
+	}
+	ancestor, err := git.NewOid(notesOID)
 	if err != nil {
-		noteTxt = ""
-		err = nil
-	} else {
-		noteTxt = n.Message()
+		return false
+	}
+	if ancestor.Equal(r.notesOID) {
+		return true
 	}
+	descendant, err := r.repo.DescendantOf(r.notesOID, ancestor)
+	return err == nil && descendant
+}
 
-	for _, note := range notes {
-		noteID, err := git.NewOid(note[1])
-		if err == nil {
-			n, err = repo.Notes.Read("refs/notes/"+nameSpace, noteID)
+// NoteIDs returns the note blob ids of a commit and the squashed commits referenced in its message,
+// the ids change when any of the notes read for the commit change
+func (r *NoteReader) NoteIDs(commitID, message string) []string {
+	ids := []string{}
+	if id, ok := r.notes[commitID]; ok {
+		ids = append(ids, id.String())
+	}
+	for _, squashed := range squashedCommitRegex.FindAllStringSubmatch(message, -1) {
+		if id, ok := r.notes[squashed[1]]; ok {
+			ids = append(ids, id.String())
 		}
+	}
+	return ids
+}
+
+// Free releases the repository
+func (r *NoteReader) Free() {
+	if r.mm != nil {
+		r.mm.Free()
+	}
+	r.repo.Free()
+}
+
+func (r *NoteReader) readNote(id *git.Oid) (string, bool) {
+	noteID, ok := r.notes[id.String()]
+	if !ok {
+		return "", false
+	}
+	blob, err := r.repo.LookupBlob(noteID)
+	if err != nil {
+		return "", false
+	}
+	defer blob.Free()
+	return string(blob.Contents()), true
+}
+
+var squashedCommitRegex = regexp.MustCompile(`commit\s+([\dabcdef]*)\r?\n`)
+
+// commitNote returns the commit's details and note, readNote returns the note text for a commit id
+func commitNote(commit *git.Commit, mm *git.Mailmap, detail StatsDetail,
+	readNote func(id *git.Oid) (string, bool)) (CommitNote, error) {
+
+	noteTxt, _ := readNote(commit.Id())
+
+	for _, squashed := range squashedCommitRegex.FindAllStringSubmatch(commit.Message(), -1) {
+		noteID, err := git.NewOid(squashed[1])
 		if err != nil {
 			continue
 		}
-		noteTxt += "\n" + n.Message()
-
+		if txt, ok := readNote(noteID); ok {
+			noteTxt += "\n" + txt
+		}
 	}
 
 	stats := CommitStats{}
-	if calcStats {
-		stats, err = DiffParentCommit(commit)
+	if detail != StatsOff {
+		var err error
+		stats, err = DiffParentCommit(commit, detail == StatsFiles)
 		if err != nil {
 			return CommitNote{}, err
 		}
 	}
 
+	author := resolveAuthor(mm, commit.Author())
+
 	return CommitNote{
 		ID:      commit.Object.Id().String(),
 		OID:     commit.Object.Id(),
 		Summary: commit.Summary(),
 		Message: commit.Message(),
-		Author:  commit.Author().Name,
-		Email:   commit.Author().Email,
-		When:    commit.Author().When,
+		Author:  author.Name,
+		Email:   author.Email,
+		When:    author.When,
 		Note:    noteTxt,
 		Stats:   stats,
 	}, nil
 }
 
+// openMailmap loads the repository's .mailmap, nil is returned if it can't be loaded
+func openMailmap(repo *git.Repository) *git.Mailmap {
+	mm, err := git.NewMailmapFromRepository(repo)
+	if err != nil {
+		return nil
+	}
+	return mm
+}
+
+// resolveAuthor maps an author signature to its canonical identity using the mailmap
+func resolveAuthor(mm *git.Mailmap, sig *git.Signature) *git.Signature {
+	if mm == nil {
+		return sig
+	}
+	resolved, err := mm.ResolveSignature(sig)
+	if err != nil {
+		return sig
+	}
+	return resolved
+}
+
 func RewriteNote(oldHash, newHash, nameSpace string, wd ...string) error {
-	oldNote, err := ReadNote(oldHash, nameSpace, true, wd...)
+	oldNote, err := ReadNote(oldHash, nameSpace, StatsCommit, wd...)
 	if err != nil {
 		return err
 	}
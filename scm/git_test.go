@@ -5,6 +5,7 @@
 package scm
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -12,8 +13,10 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DEVELOPEST/gtm-core/util"
+	"github.com/libgit2/git2go"
 )
 
 func TestWorkdir(t *testing.T) {
@@ -108,6 +111,184 @@ func TestCommitIDs(t *testing.T) {
 	}
 }
 
+func TestCommitIDsRevisions(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	repo.Seed()
+
+	first, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage("README")))
+	util.CheckFatal(t, err)
+	defer first.Free()
+	_, err = repo.Repo().CreateBranch("feature", first, false)
+	util.CheckFatal(t, err)
+
+	repo.SaveFile("README", "", "bar\n")
+	repo.Commit(repo.Stage("README"))
+
+	workdir := repo.Workdir()
+
+	tests := []struct {
+		revisions []string
+		want      int
+	}{
+		{[]string{}, 3},
+		{[]string{"feature..HEAD"}, 1},
+		{[]string{"HEAD", "^feature"}, 1},
+		{[]string{"feature"}, 2},
+		{[]string{"--branches"}, 3},
+		{[]string{"--all", "feature"}, 3},
+	}
+
+	for _, tc := range tests {
+		commits, err := CommitIDs(CommitLimiter{Revisions: tc.revisions}, workdir)
+		if err != nil {
+			t.Errorf("CommitIDs(%v) error, %s", tc.revisions, err)
+		}
+		if len(commits) != tc.want {
+			t.Errorf("CommitIDs(%v) want %d commits, got %d", tc.revisions, tc.want, len(commits))
+		}
+	}
+
+	if _, err := CommitIDs(CommitLimiter{Revisions: []string{"--bogus"}}, workdir); err == nil {
+		t.Errorf("CommitIDs(--bogus) want error, got nil")
+	}
+}
+
+func TestCommitIDsTagRange(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	repo.Seed()
+
+	for _, tag := range []string{"v1.0", "v1.1"} {
+		repo.SaveFile("README", "", tag)
+		commit, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage("README")))
+		util.CheckFatal(t, err)
+		_, err = repo.Repo().Tags.CreateLightweight(tag, commit, false)
+		util.CheckFatal(t, err)
+		commit.Free()
+	}
+	repo.SaveFile("README", "", "unreleased")
+	repo.Commit(repo.Stage("README"))
+
+	workdir := repo.Workdir()
+
+	tests := []struct {
+		sinceTag, untilTag string
+		revisions          []string
+		want               int
+	}{
+		{"", "", []string{}, 4},
+		{"v1.0", "", []string{}, 2},
+		{"", "v1.1", []string{}, 3},
+		{"v1.0", "v1.1", []string{}, 1},
+		{"v1.1", "", []string{"HEAD~1"}, 0},
+	}
+
+	for _, tc := range tests {
+		limiter := CommitLimiter{SinceTag: tc.sinceTag, UntilTag: tc.untilTag, Revisions: tc.revisions}
+		commits, err := CommitIDs(limiter, workdir)
+		if err != nil {
+			t.Errorf("CommitIDs(%s..%s) error, %s", tc.sinceTag, tc.untilTag, err)
+		}
+		if len(commits) != tc.want {
+			t.Errorf("CommitIDs(%s..%s) want %d commits, got %d", tc.sinceTag, tc.untilTag, tc.want, len(commits))
+		}
+	}
+
+	if _, err := CommitIDs(CommitLimiter{UntilTag: "v9.9"}, workdir); err == nil {
+		t.Errorf("CommitIDs(..v9.9) want error, got nil")
+	}
+}
+
+func TestMatchUncommitted(t *testing.T) {
+	tests := []struct {
+		limiter CommitLimiter
+		want    bool
+	}{
+		{CommitLimiter{}, true},
+		{CommitLimiter{Author: "Om Hack", HasAuthor: true}, true},
+		{CommitLimiter{Author: "nobody", HasAuthor: true}, false},
+		{CommitLimiter{Emails: []string{"RANDOM@hacker.com"}, HasEmails: true}, true},
+		{CommitLimiter{Emails: []string{"nobody@hacker.com"}, HasEmails: true}, false},
+		{CommitLimiter{Branch: "mast*", HasBranch: true}, true},
+		{CommitLimiter{Branch: "feature/*", HasBranch: true}, false},
+		{CommitLimiter{Message: "commit", HasMessage: true}, false},
+		{CommitLimiter{Revisions: []string{"HEAD"}}, false},
+		{CommitLimiter{SinceTag: "v1.0"}, true},
+		{CommitLimiter{UntilTag: "v1.0"}, false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.limiter.MatchUncommitted("Rand Om Hacker", "random@hacker.com", "master"); got != tc.want {
+			t.Errorf("%+v MatchUncommitted() want %t, got %t", tc.limiter, tc.want, got)
+		}
+	}
+}
+
+func TestReleases(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+	repo.Seed()
+
+	// tags are released in commit order, v1.0-rc and v1.0 tag the same commit
+	for _, tags := range [][]string{{"v1.0", "v1.0-rc"}, {"v0.9"}} {
+		repo.SaveFile("README", "", tags[0])
+		commit, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage("README")))
+		util.CheckFatal(t, err)
+		for _, tag := range tags {
+			_, err = repo.Repo().Tags.CreateLightweight(tag, commit, false)
+			util.CheckFatal(t, err)
+		}
+		commit.Free()
+	}
+
+	// annotated tags of the same commit are ordered by tagger date
+	repo.SaveFile("README", "", "annotated")
+	commit, err := repo.Repo().LookupCommit(repo.Commit(repo.Stage("README")))
+	util.CheckFatal(t, err)
+	for _, tag := range []struct {
+		name string
+		when time.Time
+	}{
+		{"release-a", time.Date(2013, 03, 07, 0, 0, 0, 0, time.UTC)},
+		{"release-b", time.Date(2013, 03, 06, 0, 0, 0, 0, time.UTC)},
+	} {
+		sig := &git.Signature{Name: "Rand Om Hacker", Email: "random@hacker.com", When: tag.when}
+		_, err = repo.Repo().Tags.Create(tag.name, commit, sig, tag.name)
+		util.CheckFatal(t, err)
+	}
+	commit.Free()
+
+	repo.SaveFile("README", "", "unreleased")
+	repo.Commit(repo.Stage("README"))
+
+	releases, err := Releases(repo.Workdir())
+	if err != nil {
+		t.Fatalf("Releases() error, %s", err)
+	}
+
+	want := []struct {
+		tag     string
+		commits int
+	}{
+		{"v1.0-rc", 2},
+		{"v1.0", 0},
+		{"v0.9", 1},
+		{"release-b", 1},
+		{"release-a", 0},
+		{"", 1},
+	}
+	if len(releases) != len(want) {
+		t.Fatalf("Releases() want %d releases, got %+v", len(want), releases)
+	}
+	for i, w := range want {
+		if releases[i].Tag != w.tag || len(releases[i].Commits) != w.commits {
+			t.Errorf("Releases()[%d] want %s with %d commits, got %s with %d commits",
+				i, w.tag, w.commits, releases[i].Tag, len(releases[i].Commits))
+		}
+	}
+}
+
 func TestHeadCommit(t *testing.T) {
 	repo := util.NewTestRepo(t, false)
 	defer repo.Remove()
@@ -158,7 +339,7 @@ func TestNote(t *testing.T) {
 		t.Errorf("HeadCommit error, %s", err)
 	}
 
-	note, err := ReadNote(commit.ID, "gtm-data", true, workdir)
+	note, err := ReadNote(commit.ID, "gtm-data", StatsCommit, workdir)
 	if err != nil {
 		t.Errorf("ReadNote error, %s", err)
 	}
@@ -185,7 +366,7 @@ func TestNote(t *testing.T) {
 		t.Errorf("HeadCommit error, %s", err)
 	}
 
-	note, err = ReadNote(commit.ID, "gtm-data", true)
+	note, err = ReadNote(commit.ID, "gtm-data", StatsCommit)
 	if err != nil {
 		t.Errorf("ReadNote error, %s", err)
 	}
@@ -196,6 +377,128 @@ func TestNote(t *testing.T) {
 
 }
 
+func TestNoteReader(t *testing.T) {
+	repo := util.NewTestRepo(t, false)
+	defer repo.Remove()
+
+	workdir := repo.Workdir()
+
+	repo.SaveFile("README", "", "first")
+	first := repo.Commit(repo.Stage("README"))
+	repo.SaveFile("README", "", "second")
+	second := repo.Commit(repo.Stage("README"))
+
+	noteTxt := "This is a note"
+	util.CheckFatal(t, CreateNote(noteTxt, "gtm-data", first.String(), workdir))
+
+	reader, err := NewNoteReader("gtm-data", StatsOff, workdir)
+	if err != nil {
+		t.Fatalf("NewNoteReader error, %s", err)
+	}
+	defer reader.Free()
+
+	for _, tc := range []struct {
+		id   string
+		note string
+	}{
+		{first.String(), noteTxt},
+		{second.String(), ""},
+	} {
+		got, err := reader.Read(tc.id)
+		if err != nil {
+			t.Errorf("Read(%s) error, %s", tc.id, err)
+			continue
+		}
+		if got.Note != tc.note {
+			t.Errorf("Read(%s) want note \"%s\", got \"%s\"", tc.id, tc.note, got.Note)
+		}
+
+		want, err := ReadNote(tc.id, "gtm-data", StatsOff, workdir)
+		util.CheckFatal(t, err)
+		if got.Note != want.Note || got.Author != want.Author || got.Summary != want.Summary {
+			t.Errorf("Read(%s) want %+v, got %+v", tc.id, want, got)
+		}
+	}
+
+	if _, err := reader.Read("notacommit"); err == nil {
+		t.Errorf("Read expected error for an invalid commit id but got nil")
+	}
+
+	empty, err := NewNoteReader("gtm-none", StatsOff, workdir)
+	if err != nil {
+		t.Fatalf("NewNoteReader without notes error, %s", err)
+	}
+	defer empty.Free()
+	if n, err := empty.Read(first.String()); err != nil || n.Note != "" {
+		t.Errorf("Read without notes want empty note, got \"%s\", %v", n.Note, err)
+	}
+}
+
+// benchmarkNotesRepo returns a repository with commits that all have a note
+func benchmarkNotesRepo(b *testing.B, commits int) (util.TestRepo, []string) {
+	repo := util.NewTestRepo(b, false)
+	ids := []string{}
+	for i := 0; i < commits; i++ {
+		repo.SaveFile("README", "", fmt.Sprintf("line %d\n", i))
+		id := repo.Commit(repo.Stage("README")).String()
+		util.CheckFatal(b, CreateNote("[ver:1,total:60,branch:master]\nREADME:60,1458496800:60,m", "gtm-data", id, repo.Workdir()))
+		ids = append(ids, id)
+	}
+	return repo, ids
+}
+
+func BenchmarkReadNote(b *testing.B) {
+	repo, ids := benchmarkNotesRepo(b, 100)
+	defer repo.Remove()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := ReadNote(id, "gtm-data", StatsOff, repo.Workdir()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkNoteReader(b *testing.B) {
+	repo, ids := benchmarkNotesRepo(b, 100)
+	defer repo.Remove()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader, err := NewNoteReader("gtm-data", StatsOff, repo.Workdir())
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, id := range ids {
+			if _, err := reader.Read(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+		reader.Free()
+	}
+}
+
+func BenchmarkNoteReaderStats(b *testing.B) {
+	repo, ids := benchmarkNotesRepo(b, 100)
+	defer repo.Remove()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reader, err := NewNoteReader("gtm-data", StatsCommit, repo.Workdir())
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, id := range ids {
+			if _, err := reader.Read(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+		reader.Free()
+	}
+}
+
 func TestStatus(t *testing.T) {
 	repo := util.NewTestRepo(t, false)
 	defer repo.Remove()
@@ -466,7 +769,7 @@ func TestPushFetchRemote(t *testing.T) {
 		t.Errorf("HeadCommit error, %s", err)
 	}
 
-	note, err := ReadNote(commit.ID, "gtm-data", true, localRepo2.Workdir())
+	note, err := ReadNote(commit.ID, "gtm-data", StatsCommit, localRepo2.Workdir())
 	if err != nil {
 		t.Errorf("ReadNote error, %s", err)
 	}
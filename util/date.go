@@ -6,6 +6,7 @@ package util
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/now"
@@ -46,6 +47,18 @@ func (d DateRange) Within(t time.Time) bool {
 
 }
 
+// In returns the date range with the same dates and times of day in the time zone loc,
+// i.e. a range of whole days keeps its day boundaries at midnight in loc
+func (d DateRange) In(loc *time.Location) DateRange {
+	in := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return t
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	}
+	return DateRange{Start: in(d.Start), End: in(d.End)}
+}
+
 // AfterNow returns a date range ending n days in the past
 func AfterNow(n int) DateRange {
 	end := now.New(Now()).EndOfDay().AddDate(0, 0, -n)
@@ -131,3 +144,15 @@ func LastYearRange() DateRange {
 
 	return DateRange{End: end, Start: start}
 }
+
+// ParseWeekday returns the weekday for a full or three letter day name, i.e. monday or Mon
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("Invalid weekday %s", s)
+}
@@ -211,3 +211,49 @@ func TestEndOnlyRange(t *testing.T) {
 		t.Errorf("dr.Within(%s) within %+v", testDate, dr)
 	}
 }
+
+func TestParseWeekday(t *testing.T) {
+	tests := map[string]time.Weekday{
+		"sunday": time.Sunday,
+		"Mon":    time.Monday,
+		"FRIDAY": time.Friday,
+		" sat ":  time.Saturday,
+	}
+	for s, want := range tests {
+		got, err := ParseWeekday(s)
+		if err != nil {
+			t.Errorf("ParseWeekday(%s) want error nil got %s", s, err)
+		}
+		if got != want {
+			t.Errorf("ParseWeekday(%s) want %s got %s", s, want, got)
+		}
+	}
+
+	if _, err := ParseWeekday("someday"); err == nil {
+		t.Errorf("ParseWeekday(someday) want error got nil")
+	}
+}
+
+func TestRangeIn(t *testing.T) {
+	tm, err := time.Parse("2006-Jan-02", "2015-Mar-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveNow := Now
+	defer func() { Now = saveNow }()
+	Now = func() time.Time { return tm }
+
+	loc := time.FixedZone("+0200", 2*3600)
+	got := TodayRange().In(loc)
+	want := "Tue Mar 31 00:00:00 +0200 2015 - Tue Mar 31 23:59:59 +0200 2015"
+	if got.String() != want {
+		t.Errorf("TodayRange().In(+0200) want %s got %s", want, got)
+	}
+	if !got.Start.Equal(tm.Add(-2 * time.Hour)) {
+		t.Errorf("TodayRange().In(+0200) want start %s got %s", tm.Add(-2*time.Hour), got.Start)
+	}
+
+	if got := (DateRange{End: tm}).In(loc); !got.Start.IsZero() {
+		t.Errorf("In(+0200) want zero start kept, got %s", got.Start)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"path"
+	"strings"
+)
+
+// GlobMatch returns true if the slash separated name matches the glob pattern,
+// ** matches zero or more directories and other path elements are matched with path.Match
+func GlobMatch(pattern, name string) bool {
+	return globMatch(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func globMatch(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if globMatch(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
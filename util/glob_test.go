@@ -0,0 +1,35 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"vendor/**", "vendor/github.com/pkg/errors/errors.go", true},
+		{"vendor/**", "vendor", true},
+		{"vendor/**", "src/vendor/a.go", false},
+		{"**/*.pb.go", "api/v1/service.pb.go", true},
+		{"**/*.pb.go", "service.pb.go", true},
+		{"**/*.pb.go", "service.go", false},
+		{"src/**/test/*.go", "src/test/a.go", true},
+		{"src/**/test/*.go", "src/a/b/test/a.go", true},
+		{"src/**/test/*.go", "src/a/b/test/c/a.go", false},
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"docs/*.md", "docs/README.md", true},
+		{"[", "a", false},
+	}
+
+	for _, tc := range cases {
+		if got := GlobMatch(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("GlobMatch(%s, %s) want %t, got %t", tc.pattern, tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers is the maximum number of projects processed concurrently
+var Workers = runtime.NumCPU()
+
+// Parallel calls f for 0 to n-1 with at most Workers concurrent calls and waits for all calls to return.
+// Callers store results by index to keep them in a deterministic order.
+func Parallel(n int, f func(i int)) {
+	workers := Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package util
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	saveWorkers := Workers
+	defer func() { Workers = saveWorkers }()
+	Workers = 3
+
+	var (
+		mu      sync.Mutex
+		running int
+		max     int
+	)
+	results := make([]int, 50)
+	Parallel(len(results), func(i int) {
+		mu.Lock()
+		running++
+		if running > max {
+			max = running
+		}
+		mu.Unlock()
+
+		results[i] = i * i
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	for i, r := range results {
+		if r != i*i {
+			t.Errorf("Parallel() want results[%d] %d, got %d", i, i*i, r)
+		}
+	}
+	if max > Workers {
+		t.Errorf("Parallel() want at most %d concurrent calls, got %d", Workers, max)
+	}
+
+	Parallel(0, func(i int) { t.Errorf("Parallel(0) want no calls, got call %d", i) })
+}
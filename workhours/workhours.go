@@ -0,0 +1,163 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package workhours
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/DEVELOPEST/gtm-core/util"
+)
+
+const (
+	// DefaultStart is the start of the working day
+	DefaultStart = "09:00"
+	// DefaultEnd is the end of the working day
+	DefaultEnd = "17:00"
+)
+
+// DefaultDays are the working days of the week
+var DefaultDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+// Config contains the working days and hours, i.e. 09:00 to 17:00, the IANA timezone they are in
+// and a file of holiday dates, one yyyy-mm-dd date per line optionally followed by a description
+type Config struct {
+	Days     []string `json:"days"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone"`
+	Holidays string   `json:"holidays"`
+}
+
+// Calendar decides if a time is within working hours
+type Calendar struct {
+	days map[time.Weekday]bool
+	// start and end are minutes since midnight
+	start    int
+	end      int
+	loc      *time.Location
+	holidays map[string]bool
+}
+
+// NewCalendar validates the config, fills in defaults and reads the holidays file
+func NewCalendar(c Config) (Calendar, error) {
+	cal := Calendar{days: map[time.Weekday]bool{}, loc: time.Local, holidays: map[string]bool{}}
+
+	days := c.Days
+	if len(days) == 0 {
+		days = DefaultDays
+	}
+	for _, d := range days {
+		wd, err := util.ParseWeekday(d)
+		if err != nil {
+			return Calendar{}, err
+		}
+		cal.days[wd] = true
+	}
+
+	var err error
+	if cal.start, err = parseClock(c.Start, DefaultStart); err != nil {
+		return Calendar{}, err
+	}
+	if cal.end, err = parseClock(c.End, DefaultEnd); err != nil {
+		return Calendar{}, err
+	}
+	if cal.end <= cal.start {
+		return Calendar{}, fmt.Errorf("Invalid work hours %s to %s, end must be after start", c.Start, c.End)
+	}
+
+	if c.Timezone != "" {
+		if cal.loc, err = time.LoadLocation(c.Timezone); err != nil {
+			return Calendar{}, fmt.Errorf("Invalid work hours timezone %s, %s", c.Timezone, err)
+		}
+	}
+
+	if c.Holidays != "" {
+		if cal.holidays, err = readHolidays(c.Holidays); err != nil {
+			return Calendar{}, err
+		}
+	}
+	return cal, nil
+}
+
+// parseClock returns the minutes since midnight of a hh:mm time
+func parseClock(s, def string) (int, error) {
+	if s == "" {
+		s = def
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		if s == "24:00" {
+			return 24 * 60, nil
+		}
+		return 0, fmt.Errorf("Invalid work hours time %s, must be hh:mm", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func readHolidays(p string) (map[string]bool, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read holidays %s, %s", p, err)
+	}
+	defer f.Close()
+
+	holidays := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", fields[0]); err != nil {
+			return nil, fmt.Errorf("Invalid holiday %s in %s line %d, must be yyyy-mm-dd", fields[0], p, line)
+		}
+		holidays[fields[0]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read holidays %s, %s", p, err)
+	}
+	return holidays, nil
+}
+
+// In returns the calendar with the working hours in the time zone loc instead of the configured time zone
+func (c Calendar) In(loc *time.Location) Calendar {
+	c.loc = loc
+	return c
+}
+
+// Within returns true if t is within working hours, on a working day that is not a holiday
+func (c Calendar) Within(t time.Time) bool {
+	t = t.In(c.loc)
+	if !c.days[t.Weekday()] || c.holidays[t.Format("2006-01-02")] {
+		return false
+	}
+	m := t.Hour()*60 + t.Minute()
+	return m >= c.start && m < c.end
+}
+
+// Split divides the seconds spent in the period starting at start into the seconds within and outside
+// working hours, the seconds are assumed to be spread evenly over the period
+func (c Calendar) Split(start time.Time, period time.Duration, secs int) (within, outside int) {
+	minutes := int(period / time.Minute)
+	if minutes <= 1 {
+		if c.Within(start) {
+			return secs, 0
+		}
+		return 0, secs
+	}
+
+	in := 0
+	for m := 0; m < minutes; m++ {
+		if c.Within(start.Add(time.Duration(m) * time.Minute)) {
+			in++
+		}
+	}
+	within = (secs*in + minutes/2) / minutes
+	return within, secs - within
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Michael Schenk. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package workhours
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtm")
+	if err != nil {
+		t.Fatalf("Unable to create tempory directory, %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	holidays := filepath.Join(dir, "holidays.txt")
+	if err := ioutil.WriteFile(holidays, []byte("# public holidays\n2016-03-25 Good Friday\n\n"), 0644); err != nil {
+		t.Fatalf("Unable to write holidays, %s", err)
+	}
+
+	cal, err := NewCalendar(Config{Start: "09:00", End: "17:30", Timezone: "Europe/Tallinn", Holidays: holidays})
+	if err != nil {
+		t.Fatalf("NewCalendar() want error nil, got %s", err)
+	}
+
+	loc, err := time.LoadLocation("Europe/Tallinn")
+	if err != nil {
+		t.Fatalf("Unable to load location, %s", err)
+	}
+
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{time.Date(2016, 3, 21, 9, 0, 0, 0, loc), true},
+		{time.Date(2016, 3, 21, 8, 59, 0, 0, loc), false},
+		{time.Date(2016, 3, 21, 17, 29, 0, 0, loc), true},
+		{time.Date(2016, 3, 21, 17, 30, 0, 0, loc), false},
+		// Sunday
+		{time.Date(2016, 3, 20, 12, 0, 0, 0, loc), false},
+		// holiday
+		{time.Date(2016, 3, 25, 12, 0, 0, 0, loc), false},
+		// 12:00 in Tallinn
+		{time.Date(2016, 3, 21, 10, 0, 0, 0, time.UTC), true},
+	}
+	for _, tc := range cases {
+		if got := cal.Within(tc.t); got != tc.want {
+			t.Errorf("Within(%s) want %t, got %t", tc.t, tc.want, got)
+		}
+	}
+
+	within, outside := cal.Split(time.Date(2016, 3, 21, 17, 0, 0, 0, loc), time.Hour, 1200)
+	if within != 600 || outside != 600 {
+		t.Errorf("Split(17:00, 1h, 1200) want 600 within and 600 outside, got %d and %d", within, outside)
+	}
+	within, outside = cal.Split(time.Date(2016, 3, 20, 12, 0, 0, 0, loc), time.Hour, 1200)
+	if within != 0 || outside != 1200 {
+		t.Errorf("Split(Sunday, 1h, 1200) want 0 within and 1200 outside, got %d and %d", within, outside)
+	}
+
+	// 12:00 in UTC is 21:00 in Tokyo
+	tokyo := time.FixedZone("+0900", 9*3600)
+	if got := cal.In(tokyo).Within(time.Date(2016, 3, 21, 12, 0, 0, 0, time.UTC)); got {
+		t.Errorf("In(+0900).Within(12:00 UTC) want false, got %t", got)
+	}
+	if got := cal.In(time.UTC).Within(time.Date(2016, 3, 21, 12, 0, 0, 0, time.UTC)); !got {
+		t.Errorf("In(UTC).Within(12:00 UTC) want true, got %t", got)
+	}
+}
+
+func TestNewCalendarInvalid(t *testing.T) {
+	for _, c := range []Config{
+		{Days: []string{"someday"}},
+		{Start: "9am"},
+		{Start: "17:00", End: "09:00"},
+		{Timezone: "Nowhere/Nothing"},
+		{Holidays: filepath.Join(os.TempDir(), "gtm-does-not-exist.txt")},
+	} {
+		if _, err := NewCalendar(c); err == nil {
+			t.Errorf("NewCalendar(%+v) want error, got nil", c)
+		}
+	}
+}